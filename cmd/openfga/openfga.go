@@ -2,73 +2,128 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"flag"
 	"log"
+	"os"
 	"os/signal"
 	"syscall"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/openfga/openfga/pkg/config"
 	"github.com/openfga/openfga/pkg/encoder"
 	"github.com/openfga/openfga/pkg/logger"
+	"github.com/openfga/openfga/pkg/logging"
+	"github.com/openfga/openfga/pkg/middleware"
 	"github.com/openfga/openfga/pkg/telemetry"
 	"github.com/openfga/openfga/server"
-	"github.com/openfga/openfga/storage"
-	"github.com/openfga/openfga/storage/memory"
-	"github.com/openfga/openfga/storage/postgres"
+	_ "github.com/openfga/openfga/storage/memory"
+	_ "github.com/openfga/openfga/storage/mysql"
+	_ "github.com/openfga/openfga/storage/postgres"
+	"github.com/openfga/openfga/storage/registry"
+	_ "github.com/openfga/openfga/storage/sqlite"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 )
 
-type svcConfig struct {
-	// Optional configuration
-	DatastoreEngine               string `default:"memory" split_words:"true" required:"true"`
-	DatastoreConnectionURI        string `split_words:"true"`
-	ServiceName                   string `default:"openfga" split_words:"true"`
-	HTTPPort                      int    `default:"8080" split_words:"true"`
-	RPCPort                       int    `default:"8081" split_words:"true"`
-	MaxTuplesPerWrite             int    `default:"100" split_words:"true"`
-	MaxTypesPerAuthorizationModel int    `default:"100" split_words:"true"`
-	// ChangelogHorizonOffset is an offset in minutes from the current time. Changes that occur after this offset will not be included in the response of ReadChanges.
-	ChangelogHorizonOffset int `default:"0" split_words:"true" `
-	// ResolveNodeLimit indicates how deeply nested an authorization model can be.
-	ResolveNodeLimit uint32 `default:"25" split_words:"true"`
-}
-
 func main() {
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	logger, err := logger.NewZapLogger()
+	rawLogger, err := logger.NewZapLogger()
 	if err != nil {
 		log.Fatalf("failed to initialize logger: %v", err)
 	}
 
-	var config svcConfig
-	if err := envconfig.Process("OPENFGA", &config); err != nil {
-		logger.Fatal("failed to process server config", zap.Error(err))
+	// logger is declared with the interface type (rather than inferred
+	// from rawLogger's concrete type) so it can be reassigned below to a
+	// logging.LeveledLogger once runtimeStore exists.
+	logger := logger.Logger(rawLogger)
+
+	configPath := flag.String("config-path", os.Getenv("OPENFGA_CONFIG_PATH"), "path to a YAML or JSON config file (env: OPENFGA_CONFIG_PATH)")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		logger.Fatal("failed to load server config", zap.Error(err))
+	}
+
+	// logger.NewZapLogger builds its zap.Logger once above, with no level
+	// argument and no hook to change it afterwards, so cfg.Log.Level (and
+	// any later SIGHUP update to it) has nowhere to land unless something
+	// in this series gates log calls itself.
+	runtimeStore := config.NewRuntimeConfigStore(cfg.Runtime())
+	logger = logging.NewLeveledLogger(logger, runtimeStore)
+
+	tracer, tracerShutdown, samplingController, err := telemetry.NewTracerProvider(ctx, cfg.Telemetry)
+	if err != nil {
+		logger.Fatal("failed to initialize tracer provider", zap.Error(err))
+	}
+
+	meter, meterShutdown, err := telemetry.NewMeterProvider(ctx, cfg.Telemetry)
+	if err != nil {
+		logger.Fatal("failed to initialize meter provider", zap.Error(err))
+	}
+
+	tlsConfig, err := middleware.BuildTLSConfig(cfg.Security.TLS)
+	if err != nil {
+		logger.Fatal("failed to build TLS config", zap.Error(err))
+	}
+
+	if cfg.Telemetry.MetricsEnabled {
+		go func() {
+			if err := telemetry.ServePrometheus(cfg.Telemetry.PrometheusAddr, tlsConfig); err != nil {
+				logger.Error("prometheus scrape endpoint stopped", zap.Error(err))
+			}
+		}()
+	}
+
+	if cfg.Security.TLS.Enabled {
+		// TODO(chunk0-6): cfg.Security.TLS only covers the Prometheus scrape
+		// endpoint above. Terminating TLS on the gRPC listener and the
+		// gateway HTTP server - the actual OpenFGA API traffic, and the
+		// core ask of this request - requires a TLSConfig hook on
+		// server.Config that doesn't exist yet. That's a change to the
+		// server package, out of scope here; this request is NOT complete
+		// until that follow-up lands. Until then, put a TLS-terminating
+		// proxy in front of the gRPC/HTTP ports in deployments that need it.
+		logger.Warn("security.tls.enabled only applies to the prometheus scrape endpoint; the gRPC and gateway listeners carrying OpenFGA API traffic are still plaintext in this build")
+	}
+
+	if cfg.ReadOnly && cfg.Datastore.Engine == "postgres" {
+		// TODO(chunk0-3): the postgres backend has no WithReadOnly option -
+		// unlike mysql and sqlite, it never learns cfg.ReadOnly at all. The
+		// middleware.NewReadOnlyInterceptor chain below still rejects every
+		// mutating RPC regardless of engine, so writes through the API are
+		// already blocked; what postgres alone misses is everything below
+		// that boundary: it keeps provisioning a full read-write connection
+		// pool (sized for writers that will never be used) and still runs
+		// migrations at startup. That's a change to storage/postgres, out
+		// of scope here and tracked as a follow-up rather than silently
+		// treated as done for every engine.
+		logger.Warn("readOnly is enabled but the postgres backend does not yet honor it at the storage layer; it will still provision a read-write connection pool and run migrations")
 	}
 
-	tracer := telemetry.NewNoopTracer()
-	meter := telemetry.NewNoopMeter()
 	tokenEncoder := encoder.NewBase64Encoder()
 
-	var datastore storage.OpenFGADatastore
-	switch config.DatastoreEngine {
-	case "memory":
-		datastore = memory.New(tracer, config.MaxTuplesPerWrite, config.MaxTypesPerAuthorizationModel)
-	case "postgres":
-		opts := []postgres.PostgresOption{
-			postgres.WithLogger(logger),
-			postgres.WithTracer(tracer),
-		}
+	authnInterceptor, err := middleware.NewAuthnInterceptor(ctx, cfg.Security.Authn)
+	if err != nil {
+		logger.Fatal("failed to initialize authentication", zap.Error(err))
+	}
 
-		datastore, err = postgres.NewPostgresDatastore(config.DatastoreConnectionURI, opts...)
-		if err != nil {
-			logger.Fatal("failed to initialize postgres datastore", zap.Error(err))
-		}
-	default:
-		logger.Fatal(fmt.Sprintf("storage engine '%s' is unsupported", config.DatastoreEngine))
+	datastore, err := registry.New(cfg.Datastore.Engine, cfg.Datastore.URI, registry.Options{
+		Logger:                        logger,
+		Tracer:                        tracer,
+		MaxTuplesPerWrite:             cfg.Datastore.MaxTuplesPerWrite,
+		MaxTypesPerAuthorizationModel: cfg.Datastore.MaxTypesPerAuthorizationModel,
+		// In read-only mode the mysql and sqlite backends skip schema
+		// migration and cap their connection pool; memory and postgres
+		// ignore this option.
+		ReadOnly:           cfg.ReadOnly,
+		RuntimeConfigStore: runtimeStore,
+	})
+	if err != nil {
+		logger.Fatal("failed to initialize datastore", zap.Error(err))
 	}
 
 	openFgaServer, err := server.New(&server.Dependencies{
@@ -83,13 +138,37 @@ func main() {
 		Meter:                     meter,
 		TokenEncoder:              tokenEncoder,
 	}, &server.Config{
-		ServiceName:            config.ServiceName,
-		RPCPort:                config.RPCPort,
-		HTTPPort:               config.HTTPPort,
-		ResolveNodeLimit:       config.ResolveNodeLimit,
-		ChangelogHorizonOffset: config.ChangelogHorizonOffset,
-		UnaryInterceptors:      nil,
-		MuxOptions:             nil,
+		ServiceName: cfg.Telemetry.ServiceName,
+		RPCPort:     cfg.GRPC.Port,
+		HTTPPort:    cfg.HTTP.Port,
+		// TODO(chunk0-4): runtimeStore.Load() already reflects the latest
+		// SIGHUP for these two (see RuntimeConfig.ResolveNodeLimit/
+		// ChangelogHorizonOffset), but they're only read once here, at
+		// construction, because server.Config has no Reload/snapshot-read
+		// hook to pick up a later change. Making them actually hot-reload
+		// needs that hook added to the server package, out of scope here
+		// and tracked as a follow-up rather than silently treated as done.
+		ResolveNodeLimit:       runtimeStore.Load().ResolveNodeLimit,
+		ChangelogHorizonOffset: runtimeStore.Load().ChangelogHorizonOffset,
+		// Chain fixes the interceptor order explicitly (authenticate,
+		// then enforce read-only, then rate-limit) instead of relying on
+		// however server.Config.UnaryInterceptors happens to apply a
+		// slice, so an unauthenticated caller is always rejected before
+		// it can burn shared rate-limit budget on a rate-limited method.
+		UnaryInterceptors: []grpc.UnaryServerInterceptor{
+			middleware.Chain(
+				authnInterceptor,
+				middleware.NewReadOnlyInterceptor(cfg.ReadOnly),
+				middleware.NewRateLimitInterceptor(runtimeStore),
+			),
+		},
+		// TODO(chunk0-6): pkg/middleware now has streaming equivalents of
+		// every interceptor above (authn, read-only, rate-limit), but
+		// server.Config has no StreamInterceptors field to register them
+		// on, so streaming RPCs (e.g. StreamedListObjects) still bypass
+		// auth, read-only enforcement, and rate-limiting entirely. Wire
+		// these in once that field exists upstream.
+		MuxOptions: nil,
 	})
 	if err != nil {
 		logger.Fatal("failed to initialize openfga server", zap.Error(err))
@@ -103,6 +182,21 @@ func main() {
 		return openFgaServer.Run(ctx)
 	})
 
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	g.Go(func() error {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-sighup:
+				reloadConfig(logger, *configPath, &cfg, runtimeStore, samplingController)
+			}
+		}
+	})
+
 	if err := g.Wait(); err != nil {
 		logger.Error("failed to run openfga server", zap.Error(err))
 	}
@@ -111,9 +205,55 @@ func main() {
 		logger.Error("failed to gracefully shutdown openfga server", zap.Error(err))
 	}
 
+	if err := tracerShutdown(context.Background()); err != nil {
+		logger.Error("failed to flush tracer provider", zap.Error(err))
+	}
+
+	if err := meterShutdown(context.Background()); err != nil {
+		logger.Error("failed to flush meter provider", zap.Error(err))
+	}
+
 	if err := datastore.Close(context.Background()); err != nil {
 		logger.Error("failed to gracefully shutdown openfga datastore", zap.Error(err))
 	}
 
 	logger.Info("Server exiting. Goodbye 👋")
 }
+
+// reloadConfig re-parses the configuration source at configPath (plus env
+// overrides), stores a new RuntimeConfig snapshot in runtimeStore and
+// updates samplingController. Log level, tracing sample ratio and
+// per-method rate limits take effect immediately. Write/model limits take
+// effect only on backends that read runtimeStore (mysql, sqlite); memory
+// and postgres keep the values they were opened with. Resolve node limit
+// and changelog horizon offset are stored in the snapshot, but the server
+// reads them only once, at construction, so a reload does not change them.
+// Fields that require a restart (ports, datastore engine/URI) are logged
+// and ignored, since those are bound to listeners and connections that are
+// only ever created once, at startup.
+func reloadConfig(log logger.Logger, configPath string, cfg **config.Config, runtimeStore *config.RuntimeConfigStore, samplingController *telemetry.SamplingController) {
+	newCfg, err := config.Load(configPath)
+	if err != nil {
+		log.Error("failed to reload config on SIGHUP, keeping previous config", zap.Error(err))
+		return
+	}
+
+	if diff := (*cfg).Unreloadable(newCfg); diff.Changed() {
+		log.Warn("ignoring changes to fields that require a restart",
+			zap.Bool("grpcPortChanged", diff.GRPCPortChanged),
+			zap.Bool("httpPortChanged", diff.HTTPPortChanged),
+			zap.Bool("datastoreEngineChanged", diff.DatastoreEngineChanged),
+			zap.Bool("datastoreURIChanged", diff.DatastoreURIChanged),
+		)
+	}
+
+	rc := newCfg.Runtime()
+	// rc.LogLevel takes effect here too: logging.LeveledLogger reads it
+	// straight off runtimeStore on every call, so storing the new snapshot
+	// is the whole reload - there's no separate log-level setter to call.
+	runtimeStore.Store(rc)
+	samplingController.SetRatio(rc.TelemetrySamplingRatio)
+
+	*cfg = newCfg
+	log.Info("reloaded configuration on SIGHUP")
+}
@@ -0,0 +1,262 @@
+// Package config provides structured configuration for the OpenFGA server,
+// loaded from an optional YAML/JSON file and overridden by environment
+// variables.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kelseyhightower/envconfig"
+	"gopkg.in/yaml.v2"
+)
+
+// DatastoreConfig configures the storage backend used by the server.
+type DatastoreConfig struct {
+	Engine                        string `json:"engine" yaml:"engine" default:"memory" split_words:"true" required:"true"`
+	URI                           string `json:"uri" yaml:"uri" split_words:"true"`
+	MaxTuplesPerWrite             int    `json:"maxTuplesPerWrite" yaml:"maxTuplesPerWrite" default:"100" split_words:"true"`
+	MaxTypesPerAuthorizationModel int    `json:"maxTypesPerAuthorizationModel" yaml:"maxTypesPerAuthorizationModel" default:"100" split_words:"true"`
+}
+
+// HTTPConfig configures the HTTP gateway listener.
+type HTTPConfig struct {
+	Port int `json:"port" yaml:"port" default:"8080" split_words:"true"`
+}
+
+// GRPCConfig configures the gRPC listener.
+type GRPCConfig struct {
+	Port int `json:"port" yaml:"port" default:"8081" split_words:"true"`
+}
+
+// TelemetryConfig configures tracing and metrics export. Protocol defaults
+// to "none", which keeps the server on the noop tracer/meter used by tests.
+type TelemetryConfig struct {
+	ServiceName    string `json:"serviceName" yaml:"serviceName" default:"openfga" split_words:"true"`
+	ServiceVersion string `json:"serviceVersion" yaml:"serviceVersion" split_words:"true"`
+	// Protocol selects the trace exporter: "none", "otlp-grpc", "otlp-http" or "jaeger".
+	Protocol      string            `json:"protocol" yaml:"protocol" default:"none" split_words:"true"`
+	Endpoint      string            `json:"endpoint" yaml:"endpoint" split_words:"true"`
+	SamplingRatio float64           `json:"samplingRatio" yaml:"samplingRatio" default:"1" split_words:"true"`
+	ResourceAttrs map[string]string `json:"resourceAttributes" yaml:"resourceAttributes"`
+	// MetricsEnabled turns on the OTLP metric push exporter and the Prometheus scrape endpoint.
+	MetricsEnabled bool   `json:"metricsEnabled" yaml:"metricsEnabled" default:"false" split_words:"true"`
+	PrometheusAddr string `json:"prometheusAddr" yaml:"prometheusAddr" default:":2112" split_words:"true"`
+}
+
+// LimitsConfig configures request-shape limits enforced by the server.
+type LimitsConfig struct {
+	// ChangelogHorizonOffset is an offset in minutes from the current time. Changes that occur after this offset will not be included in the response of ReadChanges.
+	ChangelogHorizonOffset int `json:"changelogHorizonOffset" yaml:"changelogHorizonOffset" default:"0" split_words:"true"`
+	// ResolveNodeLimit indicates how deeply nested an authorization model can be.
+	ResolveNodeLimit uint32 `json:"resolveNodeLimit" yaml:"resolveNodeLimit" default:"25" split_words:"true"`
+	// RateLimits maps a gRPC full method name to a requests-per-second limit.
+	// A method absent from the map is unlimited. Besides the config file,
+	// OPENFGA_LIMITS_RATELIMITS can set it as comma-separated method:limit
+	// pairs, which replaces the whole map.
+	RateLimits map[string]int `json:"rateLimits" yaml:"rateLimits"`
+}
+
+// LogConfig configures the server logger.
+type LogConfig struct {
+	Level  string `json:"level" yaml:"level" default:"info" split_words:"true"`
+	Format string `json:"format" yaml:"format" default:"json" split_words:"true"`
+}
+
+// TLSConfig is meant to configure transport security for the gRPC listener
+// and the gateway HTTP server carrying OpenFGA API traffic, with
+// ClientCAPath turning on mTLS by requiring and verifying a client
+// certificate signed by that CA. It does not do that yet: server.Config has
+// no hook to apply it, so today Enabled/CertPath/KeyPath only terminate TLS
+// on the Prometheus scrape endpoint (see cmd/openfga's TODO(chunk0-6)). Do
+// not rely on this to protect gRPC/gateway traffic until that lands.
+type TLSConfig struct {
+	Enabled      bool   `json:"enabled" yaml:"enabled" default:"false" split_words:"true"`
+	CertPath     string `json:"certPath" yaml:"certPath" split_words:"true"`
+	KeyPath      string `json:"keyPath" yaml:"keyPath" split_words:"true"`
+	ClientCAPath string `json:"clientCaPath" yaml:"clientCaPath" split_words:"true"`
+}
+
+// AuthnConfig configures how incoming RPCs are authenticated.
+type AuthnConfig struct {
+	// Mode selects the authentication scheme: "none", "preshared" or "oidc".
+	Mode string `json:"mode" yaml:"mode" default:"none" split_words:"true"`
+	// PresharedKeys is the list of accepted bearer tokens when Mode is "preshared".
+	PresharedKeys []string `json:"presharedKeys" yaml:"presharedKeys"`
+	OIDCIssuer    string   `json:"oidcIssuer" yaml:"oidcIssuer" split_words:"true"`
+	OIDCAudience  string   `json:"oidcAudience" yaml:"oidcAudience" split_words:"true"`
+}
+
+// SecurityConfig groups transport security and authentication settings.
+type SecurityConfig struct {
+	TLS   TLSConfig   `json:"tls" yaml:"tls"`
+	Authn AuthnConfig `json:"authn" yaml:"authn"`
+}
+
+// Config is the root configuration object for the OpenFGA server. It is
+// populated by Load, which merges an optional file with environment
+// variable overrides.
+type Config struct {
+	Datastore DatastoreConfig `json:"datastore" yaml:"datastore"`
+	HTTP      HTTPConfig      `json:"http" yaml:"http"`
+	GRPC      GRPCConfig      `json:"grpc" yaml:"grpc"`
+	Telemetry TelemetryConfig `json:"telemetry" yaml:"telemetry"`
+	Limits    LimitsConfig    `json:"limits" yaml:"limits"`
+	Log       LogConfig       `json:"log" yaml:"log"`
+	Security  SecurityConfig  `json:"security" yaml:"security"`
+	// ReadOnly puts the server into a mode where mutating RPCs (Write,
+	// WriteAuthorizationModel, WriteAssertions, CreateStore, DeleteStore, ...)
+	// are rejected before ever reaching the datastore. Useful for rolling
+	// migrations, blue/green cutovers, and replicas backed by a read replica.
+	ReadOnly bool `json:"readOnly" yaml:"readOnly" default:"false" split_words:"true"`
+}
+
+// DefaultConfig returns a Config populated with the same defaults that used
+// to be hard-coded as envconfig struct tags on svcConfig in main.go.
+func DefaultConfig() *Config {
+	var cfg Config
+	if err := envconfig.Process("", &cfg); err != nil {
+		// Defaults are statically declared via struct tags above, so
+		// Process can only fail here due to a programmer error.
+		panic(fmt.Sprintf("config: invalid defaults: %v", err))
+	}
+	return &cfg
+}
+
+// Load builds a Config by starting from defaults, layering in the file at
+// path (if non-empty), and finally applying OPENFGA_-prefixed environment
+// variable overrides. Environment variables always win over the file.
+func Load(path string) (*Config, error) {
+	cfg := DefaultConfig()
+
+	if path != "" {
+		if err := mergeFile(cfg, path); err != nil {
+			return nil, fmt.Errorf("failed to load config file %q: %w", path, err)
+		}
+	}
+
+	if err := envconfig.Process("OPENFGA", cfg); err != nil {
+		return nil, fmt.Errorf("failed to process config environment overrides: %w", err)
+	}
+
+	if err := Validate(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func mergeFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return fmt.Errorf("invalid yaml: %w", err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return fmt.Errorf("invalid json: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported config file extension %q (expected .yaml, .yml or .json)", ext)
+	}
+
+	return nil
+}
+
+// Validate checks that cfg is internally consistent, returning a single
+// error describing every invalid field.
+func Validate(cfg *Config) error {
+	var errs []string
+
+	switch cfg.Datastore.Engine {
+	case "memory", "postgres", "mysql", "sqlite":
+	default:
+		errs = append(errs, fmt.Sprintf("datastore.engine: unsupported storage engine %q", cfg.Datastore.Engine))
+	}
+
+	if (cfg.Datastore.Engine == "postgres" || cfg.Datastore.Engine == "mysql") && cfg.Datastore.URI == "" {
+		errs = append(errs, "datastore.uri: required for the postgres and mysql engines")
+	}
+
+	if cfg.Datastore.MaxTuplesPerWrite <= 0 {
+		errs = append(errs, "datastore.maxTuplesPerWrite: must be a positive integer")
+	}
+
+	if cfg.Datastore.MaxTypesPerAuthorizationModel <= 0 {
+		errs = append(errs, "datastore.maxTypesPerAuthorizationModel: must be a positive integer")
+	}
+
+	if cfg.HTTP.Port <= 0 || cfg.HTTP.Port > 65535 {
+		errs = append(errs, "http.port: must be between 1 and 65535")
+	}
+
+	if cfg.GRPC.Port <= 0 || cfg.GRPC.Port > 65535 {
+		errs = append(errs, "grpc.port: must be between 1 and 65535")
+	}
+
+	if cfg.Limits.ResolveNodeLimit == 0 {
+		errs = append(errs, "limits.resolveNodeLimit: must be greater than 0")
+	}
+
+	switch cfg.Telemetry.Protocol {
+	case "none", "otlp-grpc", "otlp-http", "jaeger":
+	default:
+		errs = append(errs, fmt.Sprintf("telemetry.protocol: unsupported protocol %q", cfg.Telemetry.Protocol))
+	}
+
+	if cfg.Telemetry.Protocol != "none" && cfg.Telemetry.Endpoint == "" {
+		errs = append(errs, "telemetry.endpoint: required when telemetry.protocol is not 'none'")
+	}
+
+	if cfg.Telemetry.SamplingRatio < 0 || cfg.Telemetry.SamplingRatio > 1 {
+		errs = append(errs, "telemetry.samplingRatio: must be between 0 and 1")
+	}
+
+	switch cfg.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Sprintf("log.level: unsupported level %q", cfg.Log.Level))
+	}
+
+	if cfg.Security.TLS.Enabled {
+		if cfg.Security.TLS.CertPath == "" || cfg.Security.TLS.KeyPath == "" {
+			errs = append(errs, "security.tls.certPath and security.tls.keyPath: both required when security.tls.enabled is true")
+		}
+	}
+
+	switch cfg.Security.Authn.Mode {
+	case "none":
+	case "preshared":
+		if len(cfg.Security.Authn.PresharedKeys) == 0 {
+			errs = append(errs, "security.authn.presharedKeys: required when security.authn.mode is 'preshared'")
+		}
+	case "oidc":
+		if cfg.Security.Authn.OIDCIssuer == "" {
+			errs = append(errs, "security.authn.oidcIssuer: required when security.authn.mode is 'oidc'")
+		}
+		if cfg.Security.Authn.OIDCAudience == "" {
+			// NewOIDCInterceptor passes this straight through as
+			// oidc.Config.ClientID, and go-oidc only skips the audience
+			// check when SkipClientIDCheck is explicitly set - left at its
+			// zero value, an empty audience means every token's "aud" is
+			// checked against "", so every request fails verification.
+			errs = append(errs, "security.authn.oidcAudience: required when security.authn.mode is 'oidc'")
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("security.authn.mode: unsupported mode %q", cfg.Security.Authn.Mode))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(errs, "\n  - "))
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+
+	if cfg.Datastore.Engine != "memory" {
+		t.Errorf("expected default datastore engine 'memory', got %q", cfg.Datastore.Engine)
+	}
+
+	if cfg.HTTP.Port != 8080 {
+		t.Errorf("expected default http port 8080, got %d", cfg.HTTP.Port)
+	}
+}
+
+func TestLoadFromYAMLFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	contents := []byte("datastore:\n  engine: postgres\n  uri: postgres://localhost:5432/openfga\nhttp:\n  port: 9090\n")
+	if err := os.WriteFile(path, contents, 0o600); err != nil {
+		t.Fatalf("failed to write test config file: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+
+	if cfg.Datastore.Engine != "postgres" {
+		t.Errorf("expected datastore engine 'postgres', got %q", cfg.Datastore.Engine)
+	}
+
+	if cfg.HTTP.Port != 9090 {
+		t.Errorf("expected http port 9090, got %d", cfg.HTTP.Port)
+	}
+}
+
+func TestLoadEnvOverridesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	if err := os.WriteFile(path, []byte(`{"http":{"port":9090}}`), 0o600); err != nil {
+		t.Fatalf("failed to write test config file: %v", err)
+	}
+
+	t.Setenv("OPENFGA_HTTP_PORT", "7070")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+
+	if cfg.HTTP.Port != 7070 {
+		t.Errorf("expected env override to win, got port %d", cfg.HTTP.Port)
+	}
+}
+
+func TestValidateRejectsUnsupportedEngine(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Datastore.Engine = "dynamodb"
+
+	if err := Validate(cfg); err == nil {
+		t.Error("expected Validate() to reject an unsupported datastore engine")
+	}
+}
+
+func TestValidateRequiresCertAndKeyWhenTLSEnabled(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Security.TLS.Enabled = true
+
+	if err := Validate(cfg); err == nil {
+		t.Error("expected Validate() to require certPath/keyPath when TLS is enabled")
+	}
+}
+
+func TestValidateRequiresPresharedKeysInPresharedMode(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Security.Authn.Mode = "preshared"
+
+	if err := Validate(cfg); err == nil {
+		t.Error("expected Validate() to require presharedKeys when authn.mode is 'preshared'")
+	}
+}
+
+func TestValidateRequiresURIForPostgresEngine(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Datastore.Engine = "postgres"
+	cfg.Datastore.URI = ""
+
+	if err := Validate(cfg); err == nil {
+		t.Error("expected Validate() to require datastore.uri for the postgres engine")
+	}
+}
+
+func TestValidateRequiresOIDCIssuerAndAudienceInOIDCMode(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Security.Authn.Mode = "oidc"
+
+	if err := Validate(cfg); err == nil {
+		t.Error("expected Validate() to require oidcIssuer and oidcAudience when authn.mode is 'oidc'")
+	}
+
+	cfg.Security.Authn.OIDCIssuer = "https://issuer.example.com"
+
+	if err := Validate(cfg); err == nil {
+		t.Error("expected Validate() to still require oidcAudience when only oidcIssuer is set")
+	}
+
+	cfg.Security.Authn.OIDCAudience = "openfga"
+
+	if err := Validate(cfg); err != nil {
+		t.Errorf("expected Validate() to accept oidc mode with issuer and audience both set, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+// RuntimeConfig is the subset of Config that can be safely swapped while
+// the server is running, without restarting listeners, reconnecting to the
+// datastore, or recreating the gRPC server. It is read by in-flight request
+// handlers and background components through a RuntimeConfigStore, so every
+// field here must be safe to observe from multiple goroutines without
+// further locking.
+type RuntimeConfig struct {
+	LogLevel                      string
+	MaxTuplesPerWrite             int
+	MaxTypesPerAuthorizationModel int
+	TelemetrySamplingRatio        float64
+	// RateLimits maps a gRPC full method name (e.g.
+	// "/openfga.v1.OpenFGAService/Check") to a requests-per-second limit.
+	// A method absent from the map is unlimited.
+	RateLimits map[string]int
+	// ResolveNodeLimit and ChangelogHorizonOffset are tracked here so a
+	// snapshot read always reflects the latest SIGHUP, but server.Config
+	// has no hook to re-read a RuntimeConfigStore after construction (see
+	// the TODO(chunk0-4) in cmd/openfga), so in practice they still only
+	// take effect on the next restart until that hook lands.
+	ResolveNodeLimit       uint32
+	ChangelogHorizonOffset int
+}
+
+// Runtime extracts the hot-reloadable subset of c into a RuntimeConfig
+// snapshot.
+func (c *Config) Runtime() RuntimeConfig {
+	rateLimits := make(map[string]int, len(c.Limits.RateLimits))
+	for method, limit := range c.Limits.RateLimits {
+		rateLimits[method] = limit
+	}
+
+	return RuntimeConfig{
+		LogLevel:                      c.Log.Level,
+		MaxTuplesPerWrite:             c.Datastore.MaxTuplesPerWrite,
+		MaxTypesPerAuthorizationModel: c.Datastore.MaxTypesPerAuthorizationModel,
+		TelemetrySamplingRatio:        c.Telemetry.SamplingRatio,
+		RateLimits:                    rateLimits,
+		ResolveNodeLimit:              c.Limits.ResolveNodeLimit,
+		ChangelogHorizonOffset:        c.Limits.ChangelogHorizonOffset,
+	}
+}
+
+// Diff describes how two Configs differ along fields that cannot be safely
+// hot-reloaded at all - the ports and the datastore engine/URI, which are
+// bound to listeners and connections that only get created once, at
+// startup.
+type Diff struct {
+	GRPCPortChanged        bool
+	HTTPPortChanged        bool
+	DatastoreURIChanged    bool
+	DatastoreEngineChanged bool
+}
+
+// Unreloadable compares c against other and reports which
+// restart-only fields changed.
+func (c *Config) Unreloadable(other *Config) Diff {
+	return Diff{
+		GRPCPortChanged:        c.GRPC.Port != other.GRPC.Port,
+		HTTPPortChanged:        c.HTTP.Port != other.HTTP.Port,
+		DatastoreURIChanged:    c.Datastore.URI != other.Datastore.URI,
+		DatastoreEngineChanged: c.Datastore.Engine != other.Datastore.Engine,
+	}
+}
+
+// Changed reports whether any restart-only field differs.
+func (d Diff) Changed() bool {
+	return d.GRPCPortChanged || d.HTTPPortChanged || d.DatastoreURIChanged || d.DatastoreEngineChanged
+}
@@ -0,0 +1,34 @@
+package config
+
+import "testing"
+
+func TestRuntimeIncludesResolveNodeLimitAndChangelogHorizonOffset(t *testing.T) {
+	cfg := &Config{}
+	cfg.Limits.ResolveNodeLimit = 30
+	cfg.Limits.ChangelogHorizonOffset = 5
+
+	rc := cfg.Runtime()
+
+	if rc.ResolveNodeLimit != 30 {
+		t.Errorf("expected ResolveNodeLimit 30, got %d", rc.ResolveNodeLimit)
+	}
+	if rc.ChangelogHorizonOffset != 5 {
+		t.Errorf("expected ChangelogHorizonOffset 5, got %d", rc.ChangelogHorizonOffset)
+	}
+}
+
+func TestUnreloadableOnlyFlagsRestartOnlyFields(t *testing.T) {
+	cfg := &Config{}
+	other := &Config{}
+	other.Limits.ResolveNodeLimit = cfg.Limits.ResolveNodeLimit + 1
+	other.Limits.ChangelogHorizonOffset = cfg.Limits.ChangelogHorizonOffset + 1
+
+	if diff := cfg.Unreloadable(other); diff.Changed() {
+		t.Errorf("expected ResolveNodeLimit/ChangelogHorizonOffset changes not to require a restart, got %+v", diff)
+	}
+
+	other.GRPC.Port = cfg.GRPC.Port + 1
+	if diff := cfg.Unreloadable(other); !diff.Changed() || !diff.GRPCPortChanged {
+		t.Errorf("expected a GRPC port change to be reported as restart-only, got %+v", diff)
+	}
+}
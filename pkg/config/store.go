@@ -0,0 +1,28 @@
+package config
+
+import "sync/atomic"
+
+// RuntimeConfigStore holds the currently active RuntimeConfig behind an
+// atomic pointer so that handler goroutines can read a consistent snapshot
+// without locking, while a SIGHUP handler swaps in a new one.
+type RuntimeConfigStore struct {
+	ptr atomic.Pointer[RuntimeConfig]
+}
+
+// NewRuntimeConfigStore returns a store initialized with initial.
+func NewRuntimeConfigStore(initial RuntimeConfig) *RuntimeConfigStore {
+	s := &RuntimeConfigStore{}
+	s.ptr.Store(&initial)
+
+	return s
+}
+
+// Load returns the currently active RuntimeConfig snapshot.
+func (s *RuntimeConfigStore) Load() RuntimeConfig {
+	return *s.ptr.Load()
+}
+
+// Store atomically replaces the active RuntimeConfig snapshot.
+func (s *RuntimeConfigStore) Store(rc RuntimeConfig) {
+	s.ptr.Store(&rc)
+}
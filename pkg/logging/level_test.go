@@ -0,0 +1,32 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/openfga/openfga/pkg/config"
+)
+
+func TestLeveledLoggerEnabled(t *testing.T) {
+	store := config.NewRuntimeConfigStore(config.RuntimeConfig{LogLevel: "warn"})
+	l := NewLeveledLogger(nil, store)
+
+	cases := []struct {
+		level string
+		want  bool
+	}{
+		{"debug", false},
+		{"info", false},
+		{"warn", true},
+		{"error", true},
+	}
+	for _, c := range cases {
+		if got := l.enabled(c.level); got != c.want {
+			t.Errorf("enabled(%q) = %v, want %v", c.level, got, c.want)
+		}
+	}
+
+	store.Store(config.RuntimeConfig{LogLevel: "debug"})
+	if !l.enabled("debug") {
+		t.Errorf("enabled(\"debug\") after lowering level to debug = false, want true")
+	}
+}
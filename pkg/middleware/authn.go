@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openfga/openfga/pkg/config"
+	"google.golang.org/grpc"
+)
+
+// NewAuthnInterceptor builds the unary server interceptor for cfg.Mode. A
+// "none" mode returns a no-op interceptor so callers can always register
+// the result unconditionally.
+func NewAuthnInterceptor(ctx context.Context, cfg config.AuthnConfig) (grpc.UnaryServerInterceptor, error) {
+	switch cfg.Mode {
+	case "none", "":
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return handler(ctx, req)
+		}, nil
+	case "preshared":
+		return NewPresharedKeyInterceptor(cfg.PresharedKeys), nil
+	case "oidc":
+		return NewOIDCInterceptor(ctx, cfg.OIDCIssuer, cfg.OIDCAudience)
+	default:
+		return nil, fmt.Errorf("unsupported authn mode %q", cfg.Mode)
+	}
+}
+
+// NewAuthnStreamInterceptor is the streaming counterpart to
+// NewAuthnInterceptor, selecting the same mode.
+func NewAuthnStreamInterceptor(ctx context.Context, cfg config.AuthnConfig) (grpc.StreamServerInterceptor, error) {
+	switch cfg.Mode {
+	case "none", "":
+		return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, ss)
+		}, nil
+	case "preshared":
+		return NewPresharedKeyStreamInterceptor(cfg.PresharedKeys), nil
+	case "oidc":
+		return NewOIDCStreamInterceptor(ctx, cfg.OIDCIssuer, cfg.OIDCAudience)
+	default:
+		return nil, fmt.Errorf("unsupported authn mode %q", cfg.Mode)
+	}
+}
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestChainRunsInterceptorsInOrder(t *testing.T) {
+	var order []string
+
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			order = append(order, name)
+			return handler(ctx, req)
+		}
+	}
+
+	chained := Chain(record("first"), record("second"), record("third"))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		order = append(order, "handler")
+		return "ok", nil
+	}
+
+	if _, err := chained(context.Background(), nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatalf("Chain() returned unexpected error: %v", err)
+	}
+
+	expected := []string{"first", "second", "third", "handler"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected call order %v, got %v", expected, order)
+	}
+	for i, name := range expected {
+		if order[i] != name {
+			t.Fatalf("expected call order %v, got %v", expected, order)
+		}
+	}
+}
+
+func TestChainShortCircuitsOnError(t *testing.T) {
+	var secondCalled bool
+
+	failing := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return nil, context.Canceled
+	}
+	second := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		secondCalled = true
+		return handler(ctx, req)
+	}
+
+	chained := Chain(failing, second)
+
+	_, err := chained(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+
+	if err == nil {
+		t.Fatal("expected the first interceptor's error to propagate")
+	}
+	if secondCalled {
+		t.Error("expected the second interceptor not to run after the first returned an error")
+	}
+}
+
+// fakeServerStream is a minimal grpc.ServerStream double for tests that
+// only need to control/observe Context(), not send or receive messages.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeServerStream) Context() context.Context { return f.ctx }
+
+func streamHandlerCalled(called *bool) grpc.StreamHandler {
+	return func(srv interface{}, stream grpc.ServerStream) error {
+		*called = true
+		return nil
+	}
+}
+
+func TestChainStreamRunsInterceptorsInOrder(t *testing.T) {
+	var order []string
+
+	record := func(name string) grpc.StreamServerInterceptor {
+		return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			order = append(order, name)
+			return handler(srv, ss)
+		}
+	}
+
+	chained := ChainStream(record("first"), record("second"))
+
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		order = append(order, "handler")
+		return nil
+	}
+
+	if err := chained(nil, &fakeServerStream{}, &grpc.StreamServerInfo{}, handler); err != nil {
+		t.Fatalf("ChainStream() returned unexpected error: %v", err)
+	}
+
+	expected := []string{"first", "second", "handler"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected call order %v, got %v", expected, order)
+	}
+	for i, name := range expected {
+		if order[i] != name {
+			t.Fatalf("expected call order %v, got %v", expected, order)
+		}
+	}
+}
+
+func TestChainStreamShortCircuitsOnError(t *testing.T) {
+	var secondCalled bool
+
+	failing := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return context.Canceled
+	}
+	second := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		secondCalled = true
+		return handler(srv, ss)
+	}
+
+	chained := ChainStream(failing, second)
+
+	err := chained(nil, &fakeServerStream{}, &grpc.StreamServerInfo{}, streamHandlerCalled(new(bool)))
+
+	if err == nil {
+		t.Fatal("expected the first interceptor's error to propagate")
+	}
+	if secondCalled {
+		t.Error("expected the second interceptor not to run after the first returned an error")
+	}
+}
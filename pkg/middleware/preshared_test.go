@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestPresharedKeyInterceptorAcceptsKnownToken(t *testing.T) {
+	interceptor := NewPresharedKeyInterceptor([]string{"secret-token"})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret-token"))
+
+	var called bool
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handlerCalled(&called))
+
+	if err != nil {
+		t.Fatalf("expected no error for a known token, got %v", err)
+	}
+	if !called {
+		t.Error("expected handler to be invoked for a known token")
+	}
+}
+
+func TestPresharedKeyInterceptorRejectsUnknownToken(t *testing.T) {
+	interceptor := NewPresharedKeyInterceptor([]string{"secret-token"})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer wrong-token"))
+
+	var called bool
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handlerCalled(&called))
+
+	if called {
+		t.Fatal("expected handler not to be invoked for an unknown token")
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated, got %v", status.Code(err))
+	}
+}
+
+func TestPresharedKeyStreamInterceptorAcceptsKnownToken(t *testing.T) {
+	interceptor := NewPresharedKeyStreamInterceptor([]string{"secret-token"})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret-token"))
+
+	var called bool
+	err := interceptor(nil, &fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, streamHandlerCalled(&called))
+
+	if err != nil {
+		t.Fatalf("expected no error for a known token, got %v", err)
+	}
+	if !called {
+		t.Error("expected handler to be invoked for a known token")
+	}
+}
+
+func TestPresharedKeyStreamInterceptorRejectsUnknownToken(t *testing.T) {
+	interceptor := NewPresharedKeyStreamInterceptor([]string{"secret-token"})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer wrong-token"))
+
+	var called bool
+	err := interceptor(nil, &fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, streamHandlerCalled(&called))
+
+	if called {
+		t.Fatal("expected handler not to be invoked for an unknown token")
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated, got %v", status.Code(err))
+	}
+}
+
+func TestPresharedKeyInterceptorRejectsMissingToken(t *testing.T) {
+	interceptor := NewPresharedKeyInterceptor([]string{"secret-token"})
+
+	var called bool
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handlerCalled(&called))
+
+	if called {
+		t.Fatal("expected handler not to be invoked without an authorization header")
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated, got %v", status.Code(err))
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openfga/openfga/pkg/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimitInterceptorAllowsUnlimitedMethods(t *testing.T) {
+	store := config.NewRuntimeConfigStore(config.RuntimeConfig{})
+	interceptor := NewRateLimitInterceptor(store)
+	var called bool
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/openfga.v1.OpenFGAService/Check"}, handlerCalled(&called))
+
+	if err != nil {
+		t.Fatalf("expected no error for a method absent from RateLimits, got %v", err)
+	}
+	if !called {
+		t.Error("expected handler to be invoked for an unlimited method")
+	}
+}
+
+func TestRateLimitInterceptorRejectsOverLimit(t *testing.T) {
+	method := "/openfga.v1.OpenFGAService/Check"
+	store := config.NewRuntimeConfigStore(config.RuntimeConfig{RateLimits: map[string]int{method: 1}})
+	interceptor := NewRateLimitInterceptor(store)
+
+	var firstCalled, secondCalled bool
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handlerCalled(&firstCalled)); err != nil {
+		t.Fatalf("expected the first request within the limit to succeed, got %v", err)
+	}
+	if !firstCalled {
+		t.Fatal("expected handler to be invoked for the first request")
+	}
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handlerCalled(&secondCalled))
+	if secondCalled {
+		t.Fatal("expected handler not to be invoked once the limit is exhausted")
+	}
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted, got %v", status.Code(err))
+	}
+}
+
+func TestRateLimitStreamInterceptorRejectsOverLimit(t *testing.T) {
+	method := "/openfga.v1.OpenFGAService/StreamedListObjects"
+	store := config.NewRuntimeConfigStore(config.RuntimeConfig{RateLimits: map[string]int{method: 1}})
+	interceptor := NewRateLimitStreamInterceptor(store)
+
+	var firstCalled, secondCalled bool
+	if err := interceptor(nil, &fakeServerStream{}, &grpc.StreamServerInfo{FullMethod: method}, streamHandlerCalled(&firstCalled)); err != nil {
+		t.Fatalf("expected the first request within the limit to succeed, got %v", err)
+	}
+	if !firstCalled {
+		t.Fatal("expected handler to be invoked for the first request")
+	}
+
+	err := interceptor(nil, &fakeServerStream{}, &grpc.StreamServerInfo{FullMethod: method}, streamHandlerCalled(&secondCalled))
+	if secondCalled {
+		t.Fatal("expected handler not to be invoked once the limit is exhausted")
+	}
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted, got %v", status.Code(err))
+	}
+}
+
+func TestRateLimitInterceptorPicksUpReload(t *testing.T) {
+	method := "/openfga.v1.OpenFGAService/Check"
+	store := config.NewRuntimeConfigStore(config.RuntimeConfig{RateLimits: map[string]int{method: 1}})
+	interceptor := NewRateLimitInterceptor(store)
+
+	var called bool
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handlerCalled(&called)); err != nil {
+		t.Fatalf("expected the first request within the limit to succeed, got %v", err)
+	}
+
+	store.Store(config.RuntimeConfig{RateLimits: map[string]int{}})
+
+	var calledAfterReload bool
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handlerCalled(&calledAfterReload))
+	if err != nil {
+		t.Fatalf("expected the limit removal to take effect immediately, got %v", err)
+	}
+	if !calledAfterReload {
+		t.Error("expected handler to be invoked once the rate limit was lifted")
+	}
+}
@@ -0,0 +1,49 @@
+// Package middleware provides gRPC interceptors shared by the OpenFGA
+// server binary.
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mutatingMethods are the full gRPC method names that write to the
+// datastore. They are rejected outright when the server runs in read-only
+// mode, before the handler (and therefore the datastore) is ever invoked.
+var mutatingMethods = map[string]bool{
+	"/openfga.v1.OpenFGAService/Write":                   true,
+	"/openfga.v1.OpenFGAService/WriteAuthorizationModel": true,
+	"/openfga.v1.OpenFGAService/WriteAssertions":         true,
+	"/openfga.v1.OpenFGAService/CreateStore":             true,
+	"/openfga.v1.OpenFGAService/DeleteStore":             true,
+}
+
+// NewReadOnlyInterceptor returns a unary server interceptor that rejects
+// mutating RPCs with a FailedPrecondition error when enabled is true. The
+// gRPC-gateway proxies HTTP requests through this same interceptor, so
+// enabling it also turns the corresponding HTTP routes into 4xx responses.
+func NewReadOnlyInterceptor(enabled bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if enabled && mutatingMethods[info.FullMethod] {
+			return nil, status.Errorf(codes.FailedPrecondition, "server is running in read-only mode: %s is disabled", info.FullMethod)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// NewReadOnlyStreamInterceptor is the streaming counterpart to
+// NewReadOnlyInterceptor, rejecting the same mutatingMethods before
+// handler ever sees the stream.
+func NewReadOnlyStreamInterceptor(enabled bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if enabled && mutatingMethods[info.FullMethod] {
+			return status.Errorf(codes.FailedPrecondition, "server is running in read-only mode: %s is disabled", info.FullMethod)
+		}
+
+		return handler(srv, ss)
+	}
+}
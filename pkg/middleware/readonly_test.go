@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func handlerCalled(called *bool) grpc.UnaryHandler {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		*called = true
+		return "ok", nil
+	}
+}
+
+func TestReadOnlyInterceptorRejectsWritesWhenEnabled(t *testing.T) {
+	interceptor := NewReadOnlyInterceptor(true)
+	var called bool
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/openfga.v1.OpenFGAService/Write"}, handlerCalled(&called))
+
+	if called {
+		t.Fatal("expected handler not to be invoked in read-only mode")
+	}
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", status.Code(err))
+	}
+}
+
+func TestReadOnlyInterceptorAllowsReadsWhenEnabled(t *testing.T) {
+	interceptor := NewReadOnlyInterceptor(true)
+	var called bool
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/openfga.v1.OpenFGAService/Check"}, handlerCalled(&called))
+
+	if err != nil {
+		t.Fatalf("expected no error for a read RPC, got %v", err)
+	}
+	if !called {
+		t.Error("expected handler to be invoked for a read RPC")
+	}
+}
+
+func TestReadOnlyStreamInterceptorRejectsWritesWhenEnabled(t *testing.T) {
+	interceptor := NewReadOnlyStreamInterceptor(true)
+	var called bool
+
+	err := interceptor(nil, &fakeServerStream{}, &grpc.StreamServerInfo{FullMethod: "/openfga.v1.OpenFGAService/Write"}, streamHandlerCalled(&called))
+
+	if called {
+		t.Fatal("expected handler not to be invoked in read-only mode")
+	}
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", status.Code(err))
+	}
+}
+
+func TestReadOnlyInterceptorAllowsWritesWhenDisabled(t *testing.T) {
+	interceptor := NewReadOnlyInterceptor(false)
+	var called bool
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/openfga.v1.OpenFGAService/Write"}, handlerCalled(&called))
+
+	if err != nil {
+		t.Fatalf("expected no error when read-only mode is disabled, got %v", err)
+	}
+	if !called {
+		t.Error("expected handler to be invoked when read-only mode is disabled")
+	}
+}
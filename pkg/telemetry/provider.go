@@ -0,0 +1,138 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+
+	"github.com/openfga/openfga/pkg/config"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/sdk/metric"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// Shutdown flushes and tears down a provider that was installed by
+// NewTracerProvider or NewMeterProvider.
+type Shutdown func(context.Context) error
+
+func newResource(cfg config.TelemetryConfig) (*sdkresource.Resource, error) {
+	attrs := []attribute.KeyValue{
+		semconv.ServiceNameKey.String(cfg.ServiceName),
+	}
+	if cfg.ServiceVersion != "" {
+		attrs = append(attrs, semconv.ServiceVersionKey.String(cfg.ServiceVersion))
+	}
+	for k, v := range cfg.ResourceAttrs {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+
+	return sdkresource.Merge(sdkresource.Default(), sdkresource.NewSchemaless(attrs...))
+}
+
+// NewTracerProvider builds a trace.TracerProvider from cfg, exporting to
+// OTLP/gRPC, OTLP/HTTP or Jaeger depending on cfg.Protocol. If cfg.Protocol
+// is "none" it returns a noop tracer and a no-op shutdown func. The returned
+// SamplingController lets a SIGHUP handler adjust the sampling ratio live;
+// it is a harmless no-op controller in the noop case.
+func NewTracerProvider(ctx context.Context, cfg config.TelemetryConfig) (Tracer, Shutdown, *SamplingController, error) {
+	controller := newSamplingController(cfg.SamplingRatio)
+
+	if cfg.Protocol == "none" {
+		return NewNoopTracer(), func(context.Context) error { return nil }, controller, nil
+	}
+
+	res, err := newResource(cfg)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to build telemetry resource: %w", err)
+	}
+
+	exporter, err := newSpanExporter(ctx, cfg)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to build span exporter: %w", err)
+	}
+
+	provider := trace.NewTracerProvider(
+		trace.WithBatcher(exporter),
+		trace.WithResource(res),
+		trace.WithSampler(newDynamicSampler(controller)),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Tracer(cfg.ServiceName), provider.Shutdown, controller, nil
+}
+
+func newSpanExporter(ctx context.Context, cfg config.TelemetryConfig) (trace.SpanExporter, error) {
+	switch cfg.Protocol {
+	case "otlp-grpc":
+		return otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(cfg.Endpoint), otlptracegrpc.WithInsecure())
+	case "otlp-http":
+		return otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(cfg.Endpoint), otlptracehttp.WithInsecure())
+	case "jaeger":
+		return jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(cfg.Endpoint)))
+	default:
+		return nil, fmt.Errorf("unsupported telemetry protocol %q", cfg.Protocol)
+	}
+}
+
+// NewMeterProvider builds a metric.MeterProvider from cfg. When
+// cfg.MetricsEnabled is false it returns a noop meter. Otherwise it exports
+// via an OTLP push exporter and additionally serves a Prometheus scrape
+// endpoint on cfg.PrometheusAddr.
+func NewMeterProvider(ctx context.Context, cfg config.TelemetryConfig) (Meter, Shutdown, error) {
+	if !cfg.MetricsEnabled {
+		return NewNoopMeter(), func(context.Context) error { return nil }, nil
+	}
+
+	res, err := newResource(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build telemetry resource: %w", err)
+	}
+
+	otlpExporter, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithEndpoint(cfg.Endpoint), otlpmetricgrpc.WithInsecure())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build otlp metric exporter: %w", err)
+	}
+
+	promExporter, err := prometheus.New()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build prometheus exporter: %w", err)
+	}
+
+	provider := metric.NewMeterProvider(
+		metric.WithResource(res),
+		metric.WithReader(metric.NewPeriodicReader(otlpExporter)),
+		metric.WithReader(promExporter),
+	)
+
+	otel.SetMeterProvider(provider)
+
+	return provider.Meter(cfg.ServiceName), provider.Shutdown, nil
+}
+
+// ServePrometheus starts an HTTP server exposing a Prometheus /metrics
+// scrape endpoint on addr. It is intended to run in its own goroutine and
+// only returns once the server stops or fails to start. When tlsConfig is
+// non-nil, the scrape endpoint is served over TLS using it.
+func ServePrometheus(addr string, tlsConfig *tls.Config) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	srv := &http.Server{Addr: addr, Handler: mux, TLSConfig: tlsConfig} //nolint:gosec // scrape endpoint timeouts are not user-facing
+
+	if tlsConfig != nil {
+		return srv.ListenAndServeTLS("", "")
+	}
+
+	return srv.ListenAndServe()
+}
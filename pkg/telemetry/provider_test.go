@@ -0,0 +1,49 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openfga/openfga/pkg/config"
+)
+
+func TestNewTracerProviderNoopByDefault(t *testing.T) {
+	cfg := config.TelemetryConfig{Protocol: "none"}
+
+	tracer, shutdown, controller, err := NewTracerProvider(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewTracerProvider() returned unexpected error: %v", err)
+	}
+	if tracer == nil {
+		t.Fatal("expected a non-nil noop tracer")
+	}
+	if controller == nil {
+		t.Fatal("expected a non-nil sampling controller")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("expected noop shutdown to succeed, got: %v", err)
+	}
+}
+
+func TestNewMeterProviderNoopWhenMetricsDisabled(t *testing.T) {
+	cfg := config.TelemetryConfig{MetricsEnabled: false}
+
+	meter, shutdown, err := NewMeterProvider(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewMeterProvider() returned unexpected error: %v", err)
+	}
+	if meter == nil {
+		t.Fatal("expected a non-nil noop meter")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("expected noop shutdown to succeed, got: %v", err)
+	}
+}
+
+func TestNewTracerProviderRejectsUnsupportedProtocol(t *testing.T) {
+	cfg := config.TelemetryConfig{Protocol: "zipkin", Endpoint: "localhost:1234"}
+
+	if _, _, _, err := NewTracerProvider(context.Background(), cfg); err == nil {
+		t.Error("expected an error for an unsupported telemetry protocol")
+	}
+}
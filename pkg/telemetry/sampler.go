@@ -0,0 +1,46 @@
+package telemetry
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SamplingController lets a SIGHUP handler adjust the tracer's sampling
+// ratio without tearing down and recreating the TracerProvider.
+type SamplingController struct {
+	ratio atomic.Pointer[float64]
+}
+
+// SetRatio atomically updates the sampling ratio applied to traces started
+// after this call returns.
+func (c *SamplingController) SetRatio(ratio float64) {
+	c.ratio.Store(&ratio)
+}
+
+func newSamplingController(initial float64) *SamplingController {
+	c := &SamplingController{}
+	c.SetRatio(initial)
+	return c
+}
+
+// dynamicSampler is a trace.Sampler that consults a SamplingController on
+// every sampling decision, instead of the fixed ratio trace.TraceIDRatioBased
+// captures at construction time.
+type dynamicSampler struct {
+	controller *SamplingController
+}
+
+func newDynamicSampler(controller *SamplingController) trace.Sampler {
+	return &dynamicSampler{controller: controller}
+}
+
+func (s *dynamicSampler) ShouldSample(parameters trace.SamplingParameters) trace.SamplingResult {
+	ratio := *s.controller.ratio.Load()
+	return trace.TraceIDRatioBased(ratio).ShouldSample(parameters)
+}
+
+func (s *dynamicSampler) Description() string {
+	return fmt.Sprintf("DynamicSampler{ratio=%v}", *s.controller.ratio.Load())
+}
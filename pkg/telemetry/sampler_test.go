@@ -0,0 +1,17 @@
+package telemetry
+
+import "testing"
+
+func TestSamplingControllerSetRatio(t *testing.T) {
+	controller := newSamplingController(1)
+	sampler := newDynamicSampler(controller)
+
+	before := sampler.Description()
+
+	controller.SetRatio(0.1)
+
+	after := sampler.Description()
+	if before == after {
+		t.Errorf("expected sampler description to change after SetRatio, got %q both times", before)
+	}
+}
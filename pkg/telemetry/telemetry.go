@@ -0,0 +1,28 @@
+// Package telemetry wires the OpenFGA server to OpenTelemetry tracing and
+// metrics, falling back to noop implementations when telemetry is disabled.
+package telemetry
+
+import (
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer is the tracing interface used throughout the server and storage
+// packages.
+type Tracer = trace.Tracer
+
+// Meter is the metrics interface used throughout the server and storage
+// packages.
+type Meter = metric.Meter
+
+// NewNoopTracer returns a Tracer that records nothing. It is the default
+// used in tests and whenever telemetry is disabled in config.
+func NewNoopTracer() Tracer {
+	return trace.NewNoopTracerProvider().Tracer("openfga")
+}
+
+// NewNoopMeter returns a Meter that records nothing. It is the default used
+// in tests and whenever telemetry is disabled in config.
+func NewNoopMeter() Meter {
+	return metric.NewNoopMeterProvider().Meter("openfga")
+}
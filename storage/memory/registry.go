@@ -0,0 +1,12 @@
+package memory
+
+import (
+	"github.com/openfga/openfga/storage"
+	"github.com/openfga/openfga/storage/registry"
+)
+
+func init() {
+	registry.Register("memory", func(uri string, opts registry.Options) (storage.OpenFGADatastore, error) {
+		return New(opts.Tracer, opts.MaxTuplesPerWrite, opts.MaxTypesPerAuthorizationModel), nil
+	})
+}
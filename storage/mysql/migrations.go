@@ -0,0 +1,57 @@
+package mysql
+
+// schema holds the MySQL DDL for a fresh OpenFGA database. It mirrors the
+// table layout of storage/postgres's migrations: stores, authorization
+// models (with a raw serialized payload plus the fields needed for
+// pagination/versioning), tuples, changelog, and assertions.
+const schema = `
+CREATE TABLE IF NOT EXISTS store (
+	id         VARCHAR(26) PRIMARY KEY,
+	name       VARCHAR(255) NOT NULL,
+	created_at DATETIME(6) NOT NULL,
+	updated_at DATETIME(6) NOT NULL,
+	deleted_at DATETIME(6) NULL
+);
+
+CREATE TABLE IF NOT EXISTS authorization_model (
+	store      VARCHAR(26) NOT NULL,
+	id         VARCHAR(26) NOT NULL,
+	type_definitions LONGBLOB NOT NULL,
+	schema_version   VARCHAR(10) NOT NULL,
+	created_at DATETIME(6) NOT NULL,
+	PRIMARY KEY (store, id)
+);
+
+CREATE TABLE IF NOT EXISTS tuple (
+	store       VARCHAR(26) NOT NULL,
+	object_type VARCHAR(255) NOT NULL,
+	object_id   VARCHAR(255) NOT NULL,
+	relation    VARCHAR(255) NOT NULL,
+	user_ref    VARCHAR(512) NOT NULL,
+	ulid        VARCHAR(26) NOT NULL,
+	inserted_at DATETIME(6) NOT NULL,
+	PRIMARY KEY (store, object_type, object_id, relation, user_ref),
+	INDEX idx_tuple_store_object (store, object_type, object_id),
+	INDEX idx_tuple_ulid (ulid)
+);
+
+CREATE TABLE IF NOT EXISTS changelog (
+	store       VARCHAR(26) NOT NULL,
+	object_type VARCHAR(255) NOT NULL,
+	object_id   VARCHAR(255) NOT NULL,
+	relation    VARCHAR(255) NOT NULL,
+	user_ref    VARCHAR(512) NOT NULL,
+	operation   TINYINT NOT NULL,
+	ulid        VARCHAR(26) NOT NULL,
+	inserted_at DATETIME(6) NOT NULL,
+	PRIMARY KEY (store, ulid),
+	INDEX idx_changelog_store_type_ulid (store, object_type, ulid)
+);
+
+CREATE TABLE IF NOT EXISTS assertion (
+	store                   VARCHAR(26) NOT NULL,
+	authorization_model_id  VARCHAR(26) NOT NULL,
+	assertions              LONGBLOB NOT NULL,
+	PRIMARY KEY (store, authorization_model_id)
+);
+`
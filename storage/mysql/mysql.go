@@ -0,0 +1,595 @@
+// Package mysql implements storage.OpenFGADatastore on top of MySQL, for
+// deployments that already run a MySQL fleet and don't want to stand up
+// Postgres just for OpenFGA.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql" // registers the "mysql" database/sql driver
+	"github.com/oklog/ulid/v2"
+	openfgav1 "github.com/openfga/api/proto/openfga/v1"
+	"github.com/openfga/openfga/pkg/config"
+	"github.com/openfga/openfga/pkg/logger"
+	"github.com/openfga/openfga/pkg/telemetry"
+	"github.com/openfga/openfga/storage"
+	"github.com/openfga/openfga/storage/sqlcommon"
+)
+
+// Datastore is a MySQL-backed storage.OpenFGADatastore.
+type Datastore struct {
+	db                            *sql.DB
+	logger                        logger.Logger
+	tracer                        telemetry.Tracer
+	tokens                        *sqlcommon.ContinuationToken
+	maxTuplesPerWrite             int
+	maxTypesPerAuthorizationModel int
+	// runtimeConfigStore, when set, takes precedence over the fixed limits
+	// above so that a SIGHUP config reload is reflected without a restart.
+	runtimeConfigStore *config.RuntimeConfigStore
+	// readOnly, when set, skips schema migration (a write operation) and
+	// caps the connection pool to a size appropriate for a read-only
+	// replica instead of one sized for concurrent writes.
+	readOnly bool
+}
+
+// MySQLOption configures a Datastore, following the same functional-option
+// pattern as postgres.PostgresOption.
+type MySQLOption func(*Datastore)
+
+// WithLogger sets the logger used for diagnostic output.
+func WithLogger(l logger.Logger) MySQLOption {
+	return func(d *Datastore) { d.logger = l }
+}
+
+// WithTracer sets the tracer used to trace datastore calls.
+func WithTracer(t telemetry.Tracer) MySQLOption {
+	return func(d *Datastore) { d.tracer = t }
+}
+
+// WithMaxTuplesPerWrite overrides the default per-write tuple limit.
+func WithMaxTuplesPerWrite(n int) MySQLOption {
+	return func(d *Datastore) { d.maxTuplesPerWrite = n }
+}
+
+// WithMaxTypesPerAuthorizationModel overrides the default per-model type limit.
+func WithMaxTypesPerAuthorizationModel(n int) MySQLOption {
+	return func(d *Datastore) { d.maxTypesPerAuthorizationModel = n }
+}
+
+// WithRuntimeConfigStore makes MaxTuplesPerWrite and
+// MaxTypesPerAuthorizationModel read live from store instead of the fixed
+// values passed to WithMaxTuplesPerWrite/WithMaxTypesPerAuthorizationModel,
+// so a SIGHUP config reload takes effect without reopening the datastore.
+func WithRuntimeConfigStore(store *config.RuntimeConfigStore) MySQLOption {
+	return func(d *Datastore) { d.runtimeConfigStore = store }
+}
+
+// WithReadOnly marks the datastore as read-only, so New skips applying the
+// schema (a write operation, expected to already exist on a read replica)
+// and caps the connection pool at readOnlyMaxOpenConns instead of sizing it
+// for concurrent writers.
+func WithReadOnly(readOnly bool) MySQLOption {
+	return func(d *Datastore) { d.readOnly = readOnly }
+}
+
+// readOnlyMaxOpenConns caps the connection pool for a read-only datastore,
+// which only ever serves reads and so needs far fewer connections than a
+// pool that must also absorb write bursts.
+const readOnlyMaxOpenConns = 10
+
+// New opens a MySQL datastore at uri (a standard go-sql-driver/mysql DSN)
+// and applies the schema if it isn't already present.
+func New(uri string, opts ...MySQLOption) (*Datastore, error) {
+	db, err := sql.Open("mysql", uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mysql datastore: %w", err)
+	}
+
+	d := &Datastore{
+		db:                            db,
+		tokens:                        sqlcommon.NewContinuationToken(),
+		maxTuplesPerWrite:             100,
+		maxTypesPerAuthorizationModel: 100,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	if d.readOnly {
+		db.SetMaxOpenConns(readOnlyMaxOpenConns)
+		return d, nil
+	}
+
+	for _, stmt := range strings.Split(schema, ";\n\n") {
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" {
+			continue
+		}
+		if _, err := db.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("failed to apply mysql schema: %w", err)
+		}
+	}
+
+	return d, nil
+}
+
+// MaxTuplesPerWrite implements storage.TupleBackend.
+func (d *Datastore) MaxTuplesPerWrite() int {
+	if d.runtimeConfigStore != nil {
+		return d.runtimeConfigStore.Load().MaxTuplesPerWrite
+	}
+	return d.maxTuplesPerWrite
+}
+
+// MaxTypesPerAuthorizationModel implements storage.AuthorizationModelBackend.
+func (d *Datastore) MaxTypesPerAuthorizationModel() int {
+	if d.runtimeConfigStore != nil {
+		return d.runtimeConfigStore.Load().MaxTypesPerAuthorizationModel
+	}
+	return d.maxTypesPerAuthorizationModel
+}
+
+// Close implements storage.OpenFGADatastore.
+func (d *Datastore) Close(_ context.Context) error {
+	return d.db.Close()
+}
+
+// IsReady implements storage.OpenFGADatastore.
+func (d *Datastore) IsReady(ctx context.Context) (bool, error) {
+	if err := d.db.PingContext(ctx); err != nil {
+		return false, fmt.Errorf("mysql is not ready: %w", err)
+	}
+
+	return true, nil
+}
+
+// CreateStore implements storage.StoresBackend.
+func (d *Datastore) CreateStore(ctx context.Context, store *openfgav1.Store) (*openfgav1.Store, error) {
+	now := time.Now().UTC()
+	id := ulid.Make().String()
+
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO store (id, name, created_at, updated_at) VALUES (?, ?, ?, ?)`,
+		id, store.GetName(), now, now,
+	)
+	if err != nil {
+		return nil, sqlcommon.HandleSQLError(err)
+	}
+
+	return &openfgav1.Store{
+		Id:        id,
+		Name:      store.GetName(),
+		CreatedAt: toTimestamp(now),
+		UpdatedAt: toTimestamp(now),
+	}, nil
+}
+
+// GetStore implements storage.StoresBackend.
+func (d *Datastore) GetStore(ctx context.Context, id string) (*openfgav1.Store, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT id, name, created_at, updated_at FROM store WHERE id = ? AND deleted_at IS NULL`, id)
+
+	var s openfgav1.Store
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&s.Id, &s.Name, &createdAt, &updatedAt); err != nil {
+		return nil, sqlcommon.HandleSQLError(err)
+	}
+
+	s.CreatedAt = toTimestamp(createdAt)
+	s.UpdatedAt = toTimestamp(updatedAt)
+
+	return &s, nil
+}
+
+// DeleteStore implements storage.StoresBackend. It soft-deletes so that
+// changelog/tuple history remains attributable.
+func (d *Datastore) DeleteStore(ctx context.Context, id string) error {
+	_, err := d.db.ExecContext(ctx, `UPDATE store SET deleted_at = ? WHERE id = ?`, time.Now().UTC(), id)
+	return sqlcommon.HandleSQLError(err)
+}
+
+// ListStores implements storage.StoresBackend.
+func (d *Datastore) ListStores(ctx context.Context, opts storage.PaginationOptions) ([]*openfgav1.Store, []byte, error) {
+	after, err := d.tokens.Decode(string(opts.ContinuationToken))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT id, name, created_at, updated_at FROM store WHERE deleted_at IS NULL AND id > ? ORDER BY id LIMIT ?`,
+		after, opts.PageSize+1,
+	)
+	if err != nil {
+		return nil, nil, sqlcommon.HandleSQLError(err)
+	}
+	defer rows.Close()
+
+	var stores []*openfgav1.Store
+	for rows.Next() {
+		var s openfgav1.Store
+		var createdAt, updatedAt time.Time
+		if err := rows.Scan(&s.Id, &s.Name, &createdAt, &updatedAt); err != nil {
+			return nil, nil, sqlcommon.HandleSQLError(err)
+		}
+		s.CreatedAt = toTimestamp(createdAt)
+		s.UpdatedAt = toTimestamp(updatedAt)
+		stores = append(stores, &s)
+	}
+
+	var continuationToken string
+	if len(stores) > opts.PageSize {
+		stores = stores[:opts.PageSize]
+		continuationToken, err = d.tokens.Encode(stores[len(stores)-1].Id)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return stores, []byte(continuationToken), nil
+}
+
+// WriteAuthorizationModel implements storage.AuthorizationModelBackend.
+func (d *Datastore) WriteAuthorizationModel(ctx context.Context, store string, model *openfgav1.AuthorizationModel) error {
+	typeDefs, err := marshalTypeDefinitions(model)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.ExecContext(ctx,
+		`INSERT INTO authorization_model (store, id, type_definitions, schema_version, created_at) VALUES (?, ?, ?, ?, ?)`,
+		store, model.GetId(), typeDefs, model.GetSchemaVersion(), time.Now().UTC(),
+	)
+
+	return sqlcommon.HandleSQLError(err)
+}
+
+// ReadAuthorizationModel implements storage.AuthorizationModelBackend.
+func (d *Datastore) ReadAuthorizationModel(ctx context.Context, store, id string) (*openfgav1.AuthorizationModel, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT type_definitions, schema_version FROM authorization_model WHERE store = ? AND id = ?`, store, id)
+
+	var typeDefs []byte
+	var schemaVersion string
+	if err := row.Scan(&typeDefs, &schemaVersion); err != nil {
+		return nil, sqlcommon.HandleSQLError(err)
+	}
+
+	model, err := unmarshalTypeDefinitions(id, schemaVersion, typeDefs)
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// ReadAuthorizationModels implements storage.AuthorizationModelBackend.
+func (d *Datastore) ReadAuthorizationModels(ctx context.Context, store string, opts storage.PaginationOptions) ([]*openfgav1.AuthorizationModel, []byte, error) {
+	after, err := d.tokens.Decode(string(opts.ContinuationToken))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT id, type_definitions, schema_version FROM authorization_model WHERE store = ? AND (? = '' OR id < ?) ORDER BY id DESC LIMIT ?`,
+		store, after, after, opts.PageSize+1,
+	)
+	if err != nil {
+		return nil, nil, sqlcommon.HandleSQLError(err)
+	}
+	defer rows.Close()
+
+	var models []*openfgav1.AuthorizationModel
+	for rows.Next() {
+		var id, schemaVersion string
+		var typeDefs []byte
+		if err := rows.Scan(&id, &typeDefs, &schemaVersion); err != nil {
+			return nil, nil, sqlcommon.HandleSQLError(err)
+		}
+
+		model, err := unmarshalTypeDefinitions(id, schemaVersion, typeDefs)
+		if err != nil {
+			return nil, nil, err
+		}
+		models = append(models, model)
+	}
+
+	var continuationToken string
+	if len(models) > opts.PageSize {
+		models = models[:opts.PageSize]
+		continuationToken, err = d.tokens.Encode(models[len(models)-1].GetId())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return models, []byte(continuationToken), nil
+}
+
+// FindLatestAuthorizationModelID implements storage.AuthorizationModelBackend.
+func (d *Datastore) FindLatestAuthorizationModelID(ctx context.Context, store string) (string, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT id FROM authorization_model WHERE store = ? ORDER BY id DESC LIMIT 1`, store)
+
+	var id string
+	if err := row.Scan(&id); err != nil {
+		return "", sqlcommon.HandleSQLError(err)
+	}
+
+	return id, nil
+}
+
+// ReadAssertions implements storage.AssertionsBackend.
+func (d *Datastore) ReadAssertions(ctx context.Context, store, modelID string) ([]*openfgav1.Assertion, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT assertions FROM assertion WHERE store = ? AND authorization_model_id = ?`, store, modelID)
+
+	var raw []byte
+	if err := row.Scan(&raw); err != nil {
+		if sqlcommon.HandleSQLError(err) == sqlcommon.ErrNotFound {
+			return nil, nil
+		}
+		return nil, sqlcommon.HandleSQLError(err)
+	}
+
+	return unmarshalAssertions(raw)
+}
+
+// WriteAssertions implements storage.AssertionsBackend.
+func (d *Datastore) WriteAssertions(ctx context.Context, store, modelID string, assertions []*openfgav1.Assertion) error {
+	raw, err := marshalAssertions(assertions)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.ExecContext(ctx,
+		`INSERT INTO assertion (store, authorization_model_id, assertions) VALUES (?, ?, ?)
+		 ON DUPLICATE KEY UPDATE assertions = VALUES(assertions)`,
+		store, modelID, raw,
+	)
+
+	return sqlcommon.HandleSQLError(err)
+}
+
+// Write implements storage.TupleBackend, deleting then inserting tuples in
+// a single transaction and recording both sides in the changelog.
+func (d *Datastore) Write(ctx context.Context, store string, deletes []*openfgav1.TupleKey, writes []*openfgav1.TupleKey) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return sqlcommon.HandleSQLError(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	now := time.Now().UTC()
+
+	for _, key := range deletes {
+		objectType, objectID := splitObject(key.GetObject())
+
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM tuple WHERE store = ? AND object_type = ? AND object_id = ? AND relation = ? AND user_ref = ?`,
+			store, objectType, objectID, key.GetRelation(), key.GetUser(),
+		); err != nil {
+			return sqlcommon.HandleSQLError(err)
+		}
+
+		if err := insertChangelogEntry(ctx, tx, store, objectType, objectID, key, openfgav1.TupleOperation_TUPLE_OPERATION_DELETE, now); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range writes {
+		objectType, objectID := splitObject(key.GetObject())
+		id := ulid.Make().String()
+
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO tuple (store, object_type, object_id, relation, user_ref, ulid, inserted_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			store, objectType, objectID, key.GetRelation(), key.GetUser(), id, now,
+		); err != nil {
+			return sqlcommon.HandleSQLError(err)
+		}
+
+		if err := insertChangelogEntry(ctx, tx, store, objectType, objectID, key, openfgav1.TupleOperation_TUPLE_OPERATION_WRITE, now); err != nil {
+			return err
+		}
+	}
+
+	return sqlcommon.HandleSQLError(tx.Commit())
+}
+
+func insertChangelogEntry(ctx context.Context, tx *sql.Tx, store, objectType, objectID string, key *openfgav1.TupleKey, op openfgav1.TupleOperation, now time.Time) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO changelog (store, object_type, object_id, relation, user_ref, operation, ulid, inserted_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		store, objectType, objectID, key.GetRelation(), key.GetUser(), int(op), ulid.Make().String(), now,
+	)
+
+	return sqlcommon.HandleSQLError(err)
+}
+
+// Read implements storage.TupleBackend.
+func (d *Datastore) Read(ctx context.Context, store string, key *openfgav1.TupleKey) (storage.TupleIterator, error) {
+	objectType, objectID := splitObject(key.GetObject())
+
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT object_type, object_id, relation, user_ref FROM tuple WHERE store = ? AND object_type = ? AND (object_id = ? OR ? = '') AND (relation = ? OR ? = '')`,
+		store, objectType, objectID, objectID, key.GetRelation(), key.GetRelation(),
+	)
+	if err != nil {
+		return nil, sqlcommon.HandleSQLError(err)
+	}
+
+	return sqlcommon.NewTupleIterator(rows), nil
+}
+
+// ReadPage implements storage.TupleBackend.
+func (d *Datastore) ReadPage(ctx context.Context, store string, key *openfgav1.TupleKey, opts storage.PaginationOptions) ([]*openfgav1.Tuple, []byte, error) {
+	objectType, objectID := splitObject(key.GetObject())
+
+	after, err := d.tokens.Decode(string(opts.ContinuationToken))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT object_type, object_id, relation, user_ref, ulid FROM tuple
+		  WHERE store = ? AND object_type = ? AND (object_id = ? OR ? = '') AND (relation = ? OR ? = '') AND (? = '' OR ulid > ?)
+		  ORDER BY ulid LIMIT ?`,
+		store, objectType, objectID, objectID, key.GetRelation(), key.GetRelation(), after, after, opts.PageSize+1,
+	)
+	if err != nil {
+		return nil, nil, sqlcommon.HandleSQLError(err)
+	}
+	defer rows.Close()
+
+	var tuples []*openfgav1.Tuple
+	var ulids []string
+	for rows.Next() {
+		var objectType, objectID, relation, user, ulid string
+		if err := rows.Scan(&objectType, &objectID, &relation, &user, &ulid); err != nil {
+			return nil, nil, sqlcommon.HandleSQLError(err)
+		}
+		tuples = append(tuples, &openfgav1.Tuple{
+			Key: &openfgav1.TupleKey{
+				Object:   fmt.Sprintf("%s:%s", objectType, objectID),
+				Relation: relation,
+				User:     user,
+			},
+		})
+		ulids = append(ulids, ulid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, sqlcommon.HandleSQLError(err)
+	}
+
+	var continuationToken string
+	if len(tuples) > opts.PageSize {
+		tuples = tuples[:opts.PageSize]
+		continuationToken, err = d.tokens.Encode(ulids[opts.PageSize-1])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return tuples, []byte(continuationToken), nil
+}
+
+// ReadUserTuple implements storage.TupleBackend.
+func (d *Datastore) ReadUserTuple(ctx context.Context, store string, key *openfgav1.TupleKey) (*openfgav1.Tuple, error) {
+	objectType, objectID := splitObject(key.GetObject())
+
+	row := d.db.QueryRowContext(ctx,
+		`SELECT object_type, object_id, relation, user_ref FROM tuple WHERE store = ? AND object_type = ? AND object_id = ? AND relation = ? AND user_ref = ?`,
+		store, objectType, objectID, key.GetRelation(), key.GetUser(),
+	)
+
+	var gotType, gotID, relation, user string
+	if err := row.Scan(&gotType, &gotID, &relation, &user); err != nil {
+		return nil, sqlcommon.HandleSQLError(err)
+	}
+
+	return &openfgav1.Tuple{Key: &openfgav1.TupleKey{
+		Object:   fmt.Sprintf("%s:%s", gotType, gotID),
+		Relation: relation,
+		User:     user,
+	}}, nil
+}
+
+// ReadUsersetTuples implements storage.TupleBackend.
+func (d *Datastore) ReadUsersetTuples(ctx context.Context, store string, filter storage.ReadUsersetTuplesFilter) (storage.TupleIterator, error) {
+	objectType, objectID := splitObject(filter.Object)
+
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT object_type, object_id, relation, user_ref FROM tuple WHERE store = ? AND object_type = ? AND object_id = ? AND relation = ? AND user_ref LIKE '%#%'`,
+		store, objectType, objectID, filter.Relation,
+	)
+	if err != nil {
+		return nil, sqlcommon.HandleSQLError(err)
+	}
+
+	return sqlcommon.NewTupleIterator(rows), nil
+}
+
+// ReadStartingWithUser implements storage.TupleBackend.
+func (d *Datastore) ReadStartingWithUser(ctx context.Context, store string, filter storage.ReadStartingWithUserFilter) (storage.TupleIterator, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT object_type, object_id, relation, user_ref FROM tuple WHERE store = ? AND object_type = ? AND relation = ? AND user_ref = ?`,
+		store, filter.ObjectType, filter.Relation, filter.UserFilter,
+	)
+	if err != nil {
+		return nil, sqlcommon.HandleSQLError(err)
+	}
+
+	return sqlcommon.NewTupleIterator(rows), nil
+}
+
+// ReadChanges implements storage.ChangelogBackend.
+func (d *Datastore) ReadChanges(ctx context.Context, store, objectType string, opts storage.PaginationOptions, horizonOffset int) ([]*openfgav1.TupleChange, []byte, error) {
+	after, err := d.tokens.Decode(string(opts.ContinuationToken))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	horizon := time.Now().UTC().Add(-time.Duration(horizonOffset) * time.Minute)
+
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT object_type, object_id, relation, user_ref, operation, ulid
+		   FROM changelog
+		  WHERE store = ? AND (object_type = ? OR ? = '') AND ulid > ? AND inserted_at <= ?
+		  ORDER BY ulid
+		  LIMIT ?`,
+		store, objectType, objectType, after, horizon, opts.PageSize+1,
+	)
+	if err != nil {
+		return nil, nil, sqlcommon.HandleSQLError(err)
+	}
+	defer rows.Close()
+
+	var changes []*openfgav1.TupleChange
+	var ulids []string
+
+	for rows.Next() {
+		var gotType, gotID, relation, user, ulid string
+		var operation int
+		if err := rows.Scan(&gotType, &gotID, &relation, &user, &operation, &ulid); err != nil {
+			return nil, nil, sqlcommon.HandleSQLError(err)
+		}
+
+		changes = append(changes, &openfgav1.TupleChange{
+			TupleKey: &openfgav1.TupleKey{
+				Object:   fmt.Sprintf("%s:%s", gotType, gotID),
+				Relation: relation,
+				User:     user,
+			},
+			Operation: openfgav1.TupleOperation(operation),
+		})
+		ulids = append(ulids, ulid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, sqlcommon.HandleSQLError(err)
+	}
+
+	var continuationToken string
+	if len(changes) > opts.PageSize {
+		changes = changes[:opts.PageSize]
+		continuationToken, err = d.tokens.Encode(ulids[opts.PageSize-1])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return changes, []byte(continuationToken), nil
+}
+
+func splitObject(object string) (objectType, objectID string) {
+	parts := strings.SplitN(object, ":", 2)
+	if len(parts) != 2 {
+		return object, ""
+	}
+
+	return parts[0], parts[1]
+}
@@ -0,0 +1,33 @@
+package mysql
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/openfga/openfga/storage"
+	"github.com/openfga/openfga/storage/storagetest"
+)
+
+// TestMySQLDatastoreConformance runs the shared conformance suite against a
+// real MySQL instance. It requires OPENFGA_TEST_MYSQL_DSN (a
+// go-sql-driver/mysql DSN) to be set and is skipped otherwise, matching how
+// storage/postgres's own integration tests are gated on a live database.
+func TestMySQLDatastoreConformance(t *testing.T) {
+	dsn := os.Getenv("OPENFGA_TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("set OPENFGA_TEST_MYSQL_DSN to run the mysql conformance suite against a live database")
+	}
+
+	storagetest.RunAll(t, func(t *testing.T) storage.OpenFGADatastore {
+		t.Helper()
+
+		ds, err := New(dsn)
+		if err != nil {
+			t.Fatalf("New() returned unexpected error: %v", err)
+		}
+		t.Cleanup(func() { _ = ds.Close(context.Background()) })
+
+		return ds
+	})
+}
@@ -0,0 +1,24 @@
+package mysql
+
+import (
+	"github.com/openfga/openfga/storage"
+	"github.com/openfga/openfga/storage/registry"
+)
+
+func init() {
+	registry.Register("mysql", func(uri string, opts registry.Options) (storage.OpenFGADatastore, error) {
+		mysqlOpts := []MySQLOption{
+			WithLogger(opts.Logger),
+			WithTracer(opts.Tracer),
+			WithMaxTuplesPerWrite(opts.MaxTuplesPerWrite),
+			WithMaxTypesPerAuthorizationModel(opts.MaxTypesPerAuthorizationModel),
+			WithReadOnly(opts.ReadOnly),
+		}
+
+		if opts.RuntimeConfigStore != nil {
+			mysqlOpts = append(mysqlOpts, WithRuntimeConfigStore(opts.RuntimeConfigStore))
+		}
+
+		return New(uri, mysqlOpts...)
+	})
+}
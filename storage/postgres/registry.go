@@ -0,0 +1,15 @@
+package postgres
+
+import (
+	"github.com/openfga/openfga/storage"
+	"github.com/openfga/openfga/storage/registry"
+)
+
+func init() {
+	registry.Register("postgres", func(uri string, opts registry.Options) (storage.OpenFGADatastore, error) {
+		return NewPostgresDatastore(uri,
+			WithLogger(opts.Logger),
+			WithTracer(opts.Tracer),
+		)
+	})
+}
@@ -0,0 +1,96 @@
+// Package registry lets storage backends register themselves by name so
+// that main.go can select one by config.Config.Datastore.Engine without
+// calling each backend's constructor directly; main.go only blank-imports
+// the backend packages so their init() functions register them.
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/openfga/openfga/pkg/config"
+	"github.com/openfga/openfga/pkg/logger"
+	"github.com/openfga/openfga/pkg/telemetry"
+	"github.com/openfga/openfga/storage"
+)
+
+// Options are the dependencies a Factory needs to construct a datastore.
+// They mirror the *Option functional-option pattern used by individual
+// backends (e.g. postgres.WithLogger), collected here so the registry can
+// pass them through uniformly regardless of engine.
+type Options struct {
+	Logger                        logger.Logger
+	Tracer                        telemetry.Tracer
+	MaxTuplesPerWrite             int
+	MaxTypesPerAuthorizationModel int
+	// ReadOnly tells a backend that no writes will be made. mysql and sqlite
+	// then skip schema migration and cap their connection pool; backends
+	// that don't support it ignore it.
+	ReadOnly bool
+	// RuntimeConfigStore, when non-nil, lets a backend read MaxTuplesPerWrite
+	// and MaxTypesPerAuthorizationModel live on every call instead of the
+	// fixed values above, so a SIGHUP reload takes effect without a restart.
+	// Backends that don't support this simply ignore it and keep using the
+	// fixed values.
+	RuntimeConfigStore *config.RuntimeConfigStore
+}
+
+// Factory constructs an OpenFGADatastore for a specific engine from a
+// connection URI and a set of Options.
+type Factory func(uri string, opts Options) (storage.OpenFGADatastore, error)
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register associates name with factory. It is intended to be called from
+// a backend package's init() function, e.g.:
+//
+//	func init() {
+//		registry.Register("mysql", func(uri string, opts registry.Options) (storage.OpenFGADatastore, error) {
+//			return New(uri, WithLogger(opts.Logger))
+//		})
+//	}
+//
+// Register panics if name is already registered, since that indicates two
+// backend packages were compiled in with the same engine name.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, exists := factories[name]; exists {
+		panic(fmt.Sprintf("registry: engine %q already registered", name))
+	}
+
+	factories[name] = factory
+}
+
+// New looks up the factory registered under name and invokes it with uri
+// and opts. It returns an error naming the engine if none is registered.
+func New(name, uri string, opts Options) (storage.OpenFGADatastore, error) {
+	mu.RLock()
+	factory, ok := factories[name]
+	mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("storage engine '%s' is unsupported (known engines: %v)", name, Engines())
+	}
+
+	return factory(uri, opts)
+}
+
+// Engines returns the sorted list of currently registered engine names.
+func Engines() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,65 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/openfga/openfga/storage"
+)
+
+func TestRegisterAndNew(t *testing.T) {
+	name := "registry-test-engine"
+
+	var gotURI string
+	var gotOpts Options
+
+	Register(name, func(uri string, opts Options) (storage.OpenFGADatastore, error) {
+		gotURI = uri
+		gotOpts = opts
+		return nil, nil
+	})
+
+	opts := Options{MaxTuplesPerWrite: 42}
+	if _, err := New(name, "mem://test", opts); err != nil {
+		t.Fatalf("New() returned unexpected error: %v", err)
+	}
+
+	if gotURI != "mem://test" {
+		t.Errorf("expected factory to receive uri 'mem://test', got %q", gotURI)
+	}
+	if gotOpts.MaxTuplesPerWrite != 42 {
+		t.Errorf("expected factory to receive opts.MaxTuplesPerWrite=42, got %d", gotOpts.MaxTuplesPerWrite)
+	}
+}
+
+func TestNewUnknownEngine(t *testing.T) {
+	if _, err := New("does-not-exist", "", Options{}); err == nil {
+		t.Error("expected New() to return an error for an unregistered engine")
+	}
+}
+
+func TestRegisterPanicsOnDuplicate(t *testing.T) {
+	name := "registry-test-duplicate"
+	factory := func(uri string, opts Options) (storage.OpenFGADatastore, error) { return nil, nil }
+
+	Register(name, factory)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected Register() to panic on duplicate engine name")
+		}
+	}()
+
+	Register(name, factory)
+}
+
+func TestEnginesSorted(t *testing.T) {
+	Register("zzz-registry-test", func(uri string, opts Options) (storage.OpenFGADatastore, error) { return nil, nil })
+	Register("aaa-registry-test", func(uri string, opts Options) (storage.OpenFGADatastore, error) { return nil, nil })
+
+	engines := Engines()
+	for i := 1; i < len(engines); i++ {
+		if engines[i-1] > engines[i] {
+			t.Fatalf("expected Engines() to be sorted, got %v", engines)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Package sqlcommon holds helpers shared by the database/sql-backed
+// storage implementations (storage/mysql, storage/sqlite) so that adding a
+// new SQL backend doesn't mean re-deriving pagination, error translation,
+// and row-scanning from scratch.
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	openfgav1 "github.com/openfga/api/proto/openfga/v1"
+	"github.com/openfga/openfga/pkg/encoder"
+	"github.com/openfga/openfga/storage"
+)
+
+// ErrNotFound is returned by lookups that find no matching row. Backends
+// should translate sql.ErrNoRows to this error so callers don't need to
+// import database/sql.
+var ErrNotFound = errors.New("sqlcommon: not found")
+
+// HandleSQLError normalizes a database/sql error into a storage-level
+// error, translating "no rows" into ErrNotFound and passing everything
+// else through wrapped with context.
+func HandleSQLError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+
+	return fmt.Errorf("sqlcommon: %w", err)
+}
+
+// ContinuationToken encodes/decodes opaque pagination cursors using the
+// same base64 encoder the server hands out to API clients, so continuation
+// tokens returned by SQL backends look identical to those from other
+// backends.
+type ContinuationToken struct {
+	encoder encoder.Encoder
+}
+
+// NewContinuationToken returns a ContinuationToken backed by a base64
+// encoder.
+func NewContinuationToken() *ContinuationToken {
+	return &ContinuationToken{encoder: encoder.NewBase64Encoder()}
+}
+
+// Encode turns a raw cursor value (typically the last-seen primary key)
+// into an opaque continuation token. An empty raw value yields an empty
+// token, signaling "no more pages".
+func (c *ContinuationToken) Encode(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	return c.encoder.Encode([]byte(raw))
+}
+
+// Decode reverses Encode. An empty token decodes to an empty raw value,
+// meaning "start from the beginning".
+func (c *ContinuationToken) Decode(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+
+	raw, err := c.encoder.Decode(token)
+	if err != nil {
+		return "", fmt.Errorf("invalid continuation token: %w", err)
+	}
+
+	return string(raw), nil
+}
+
+// TupleIterator adapts *sql.Rows scanning four columns
+// (object_type, object_id, relation, user) into a storage.TupleIterator.
+type TupleIterator struct {
+	rows *sql.Rows
+}
+
+// NewTupleIterator wraps rows, which must have been produced by a query
+// selecting (object_type, object_id, relation, user_ref) in that order.
+func NewTupleIterator(rows *sql.Rows) *TupleIterator {
+	return &TupleIterator{rows: rows}
+}
+
+// Next returns the next tuple, or storage.ErrIteratorDone once exhausted.
+func (t *TupleIterator) Next(ctx context.Context) (*openfgav1.Tuple, error) {
+	if !t.rows.Next() {
+		if err := t.rows.Err(); err != nil {
+			return nil, HandleSQLError(err)
+		}
+
+		return nil, storage.ErrIteratorDone
+	}
+
+	var objectType, objectID, relation, user string
+	if err := t.rows.Scan(&objectType, &objectID, &relation, &user); err != nil {
+		return nil, HandleSQLError(err)
+	}
+
+	return &openfgav1.Tuple{
+		Key: &openfgav1.TupleKey{
+			Object:   fmt.Sprintf("%s:%s", objectType, objectID),
+			Relation: relation,
+			User:     user,
+		},
+	}, nil
+}
+
+// Stop releases the underlying rows. It is safe to call more than once.
+func (t *TupleIterator) Stop() {
+	_ = t.rows.Close()
+}
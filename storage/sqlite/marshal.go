@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"fmt"
+	"time"
+
+	openfgav1 "github.com/openfga/api/proto/openfga/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	return timestamppb.New(t)
+}
+
+func parseTimestamp(s string) *timestamppb.Timestamp {
+	t, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+// marshalTypeDefinitions serializes a model's type definitions as a
+// protobuf-encoded blob, matching the representation storage/mysql uses.
+func marshalTypeDefinitions(model *openfgav1.AuthorizationModel) ([]byte, error) {
+	raw, err := proto.Marshal(&openfgav1.TypeDefinitions{TypeDefinitions: model.GetTypeDefinitions()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal type definitions: %w", err)
+	}
+
+	return raw, nil
+}
+
+func unmarshalTypeDefinitions(id, schemaVersion string, raw []byte) (*openfgav1.AuthorizationModel, error) {
+	var defs openfgav1.TypeDefinitions
+	if err := proto.Unmarshal(raw, &defs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal type definitions: %w", err)
+	}
+
+	return &openfgav1.AuthorizationModel{
+		Id:              id,
+		SchemaVersion:   schemaVersion,
+		TypeDefinitions: defs.GetTypeDefinitions(),
+	}, nil
+}
+
+func marshalAssertions(assertions []*openfgav1.Assertion) ([]byte, error) {
+	raw, err := proto.Marshal(&openfgav1.Assertions{Assertions: assertions})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal assertions: %w", err)
+	}
+
+	return raw, nil
+}
+
+func unmarshalAssertions(raw []byte) ([]*openfgav1.Assertion, error) {
+	var a openfgav1.Assertions
+	if err := proto.Unmarshal(raw, &a); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal assertions: %w", err)
+	}
+
+	return a.GetAssertions(), nil
+}
@@ -0,0 +1,56 @@
+package sqlite
+
+// schema holds the SQLite DDL for a fresh OpenFGA database. It mirrors the
+// table layout used by storage/mysql, adapted to SQLite's more permissive
+// typing (TEXT for timestamps/IDs, stored as RFC3339).
+const schema = `
+CREATE TABLE IF NOT EXISTS store (
+	id         TEXT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL,
+	deleted_at TEXT NULL
+);
+
+CREATE TABLE IF NOT EXISTS authorization_model (
+	store            TEXT NOT NULL,
+	id               TEXT NOT NULL,
+	type_definitions BLOB NOT NULL,
+	schema_version   TEXT NOT NULL,
+	created_at       TEXT NOT NULL,
+	PRIMARY KEY (store, id)
+);
+
+CREATE TABLE IF NOT EXISTS tuple (
+	store       TEXT NOT NULL,
+	object_type TEXT NOT NULL,
+	object_id   TEXT NOT NULL,
+	relation    TEXT NOT NULL,
+	user_ref    TEXT NOT NULL,
+	ulid        TEXT NOT NULL,
+	inserted_at TEXT NOT NULL,
+	PRIMARY KEY (store, object_type, object_id, relation, user_ref)
+);
+CREATE INDEX IF NOT EXISTS idx_tuple_store_object ON tuple (store, object_type, object_id);
+CREATE INDEX IF NOT EXISTS idx_tuple_ulid ON tuple (ulid);
+
+CREATE TABLE IF NOT EXISTS changelog (
+	store       TEXT NOT NULL,
+	object_type TEXT NOT NULL,
+	object_id   TEXT NOT NULL,
+	relation    TEXT NOT NULL,
+	user_ref    TEXT NOT NULL,
+	operation   INTEGER NOT NULL,
+	ulid        TEXT NOT NULL,
+	inserted_at TEXT NOT NULL,
+	PRIMARY KEY (store, ulid)
+);
+CREATE INDEX IF NOT EXISTS idx_changelog_store_type_ulid ON changelog (store, object_type, ulid);
+
+CREATE TABLE IF NOT EXISTS assertion (
+	store                  TEXT NOT NULL,
+	authorization_model_id TEXT NOT NULL,
+	assertions             BLOB NOT NULL,
+	PRIMARY KEY (store, authorization_model_id)
+);
+`
@@ -0,0 +1,24 @@
+package sqlite
+
+import (
+	"github.com/openfga/openfga/storage"
+	"github.com/openfga/openfga/storage/registry"
+)
+
+func init() {
+	registry.Register("sqlite", func(uri string, opts registry.Options) (storage.OpenFGADatastore, error) {
+		sqliteOpts := []SQLiteOption{
+			WithLogger(opts.Logger),
+			WithTracer(opts.Tracer),
+			WithMaxTuplesPerWrite(opts.MaxTuplesPerWrite),
+			WithMaxTypesPerAuthorizationModel(opts.MaxTypesPerAuthorizationModel),
+			WithReadOnly(opts.ReadOnly),
+		}
+
+		if opts.RuntimeConfigStore != nil {
+			sqliteOpts = append(sqliteOpts, WithRuntimeConfigStore(opts.RuntimeConfigStore))
+		}
+
+		return New(uri, sqliteOpts...)
+	})
+}
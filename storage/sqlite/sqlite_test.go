@@ -0,0 +1,35 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openfga/openfga/storage"
+	"github.com/openfga/openfga/storage/storagetest"
+)
+
+func TestSQLiteDatastoreConformance(t *testing.T) {
+	storagetest.RunAll(t, func(t *testing.T) storage.OpenFGADatastore {
+		t.Helper()
+
+		ds, err := New("")
+		if err != nil {
+			t.Fatalf("New() returned unexpected error: %v", err)
+		}
+		t.Cleanup(func() { _ = ds.Close(context.Background()) })
+
+		return ds
+	})
+}
+
+func TestWithReadOnlySkipsSchemaMigration(t *testing.T) {
+	ds, err := New("", WithReadOnly(true))
+	if err != nil {
+		t.Fatalf("New() returned unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = ds.Close(context.Background()) })
+
+	if _, err := ds.ListStores(context.Background(), storage.PaginationOptions{}); err == nil {
+		t.Error("expected ListStores() to fail against a read-only datastore with no pre-existing schema")
+	}
+}
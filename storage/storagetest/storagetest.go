@@ -0,0 +1,332 @@
+// Package storagetest is a conformance suite that every storage.OpenFGADatastore
+// backend should pass. New backends wire it up with a one-line test:
+//
+//	func TestDatastore(t *testing.T) {
+//		storagetest.RunAll(t, func(t *testing.T) storage.OpenFGADatastore {
+//			ds, err := sqlite.New("")
+//			require.NoError(t, err)
+//			return ds
+//		})
+//	}
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	openfgav1 "github.com/openfga/api/proto/openfga/v1"
+	"github.com/openfga/openfga/storage"
+)
+
+// DatastoreBuilder returns a fresh, empty datastore instance for a single
+// test. Called once per subtest so backends don't need to support
+// concurrent conformance runs against the same instance.
+type DatastoreBuilder func(t *testing.T) storage.OpenFGADatastore
+
+// RunAll runs every conformance test in this package as a subtest, against
+// a fresh datastore obtained from newDatastore for each one.
+func RunAll(t *testing.T, newDatastore DatastoreBuilder) {
+	t.Run("StoreCRUD", func(t *testing.T) { testStoreCRUD(t, newDatastore(t)) })
+	t.Run("TupleWriteReadDelete", func(t *testing.T) { testTupleWriteReadDelete(t, newDatastore(t)) })
+	t.Run("ChangelogOrdering", func(t *testing.T) { testChangelogOrdering(t, newDatastore(t)) })
+	t.Run("Assertions", func(t *testing.T) { testAssertions(t, newDatastore(t)) })
+	t.Run("ModelVersioning", func(t *testing.T) { testModelVersioning(t, newDatastore(t)) })
+	t.Run("PaginationContinuation", func(t *testing.T) { testPaginationContinuation(t, newDatastore(t)) })
+}
+
+func testStoreCRUD(t *testing.T, ds storage.OpenFGADatastore) {
+	ctx := context.Background()
+
+	created, err := ds.CreateStore(ctx, &openfgav1.Store{Name: "acme"})
+	if err != nil {
+		t.Fatalf("CreateStore() returned unexpected error: %v", err)
+	}
+	if created.GetId() == "" {
+		t.Fatal("expected CreateStore() to assign a non-empty id")
+	}
+
+	got, err := ds.GetStore(ctx, created.GetId())
+	if err != nil {
+		t.Fatalf("GetStore() returned unexpected error: %v", err)
+	}
+	if got.GetName() != "acme" {
+		t.Errorf("expected store name 'acme', got %q", got.GetName())
+	}
+
+	if err := ds.DeleteStore(ctx, created.GetId()); err != nil {
+		t.Fatalf("DeleteStore() returned unexpected error: %v", err)
+	}
+
+	if _, err := ds.GetStore(ctx, created.GetId()); err == nil {
+		t.Error("expected GetStore() to fail after DeleteStore()")
+	}
+}
+
+func testTupleWriteReadDelete(t *testing.T, ds storage.OpenFGADatastore) {
+	ctx := context.Background()
+	store := mustCreateStore(t, ds, "tuple-store")
+
+	key := &openfgav1.TupleKey{Object: "document:1", Relation: "viewer", User: "user:anne"}
+
+	if err := ds.Write(ctx, store, nil, []*openfgav1.TupleKey{key}); err != nil {
+		t.Fatalf("Write() returned unexpected error: %v", err)
+	}
+
+	got, err := ds.ReadUserTuple(ctx, store, key)
+	if err != nil {
+		t.Fatalf("ReadUserTuple() returned unexpected error: %v", err)
+	}
+	if got.GetKey().GetUser() != "user:anne" {
+		t.Errorf("expected user 'user:anne', got %q", got.GetKey().GetUser())
+	}
+
+	if err := ds.Write(ctx, store, []*openfgav1.TupleKey{key}, nil); err != nil {
+		t.Fatalf("Write() (delete) returned unexpected error: %v", err)
+	}
+
+	if _, err := ds.ReadUserTuple(ctx, store, key); err == nil {
+		t.Error("expected ReadUserTuple() to fail after the tuple was deleted")
+	}
+}
+
+func testChangelogOrdering(t *testing.T, ds storage.OpenFGADatastore) {
+	ctx := context.Background()
+	store := mustCreateStore(t, ds, "changelog-store")
+
+	keys := []*openfgav1.TupleKey{
+		{Object: "document:1", Relation: "viewer", User: "user:anne"},
+		{Object: "document:2", Relation: "viewer", User: "user:bob"},
+	}
+
+	for _, key := range keys {
+		if err := ds.Write(ctx, store, nil, []*openfgav1.TupleKey{key}); err != nil {
+			t.Fatalf("Write() returned unexpected error: %v", err)
+		}
+	}
+
+	changes, _, err := ds.ReadChanges(ctx, store, "", storage.PaginationOptions{PageSize: 10}, 0)
+	if err != nil {
+		t.Fatalf("ReadChanges() returned unexpected error: %v", err)
+	}
+
+	if len(changes) != len(keys) {
+		t.Fatalf("expected %d changelog entries, got %d", len(keys), len(changes))
+	}
+	for i, key := range keys {
+		if changes[i].GetTupleKey().GetObject() != key.GetObject() {
+			t.Errorf("expected changelog entry %d for object %q, got %q", i, key.GetObject(), changes[i].GetTupleKey().GetObject())
+		}
+	}
+}
+
+func testAssertions(t *testing.T, ds storage.OpenFGADatastore) {
+	ctx := context.Background()
+	store := mustCreateStore(t, ds, "assertions-store")
+
+	assertions := []*openfgav1.Assertion{
+		{TupleKey: &openfgav1.TupleKey{Object: "document:1", Relation: "viewer", User: "user:anne"}, Expectation: true},
+	}
+
+	if err := ds.WriteAssertions(ctx, store, "model-1", assertions); err != nil {
+		t.Fatalf("WriteAssertions() returned unexpected error: %v", err)
+	}
+
+	got, err := ds.ReadAssertions(ctx, store, "model-1")
+	if err != nil {
+		t.Fatalf("ReadAssertions() returned unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].GetTupleKey().GetObject() != "document:1" {
+		t.Errorf("expected to read back the written assertion, got %v", got)
+	}
+}
+
+func testModelVersioning(t *testing.T, ds storage.OpenFGADatastore) {
+	ctx := context.Background()
+	store := mustCreateStore(t, ds, "model-store")
+
+	models := []*openfgav1.AuthorizationModel{
+		{Id: "01H000000000000000000000A", SchemaVersion: "1.1"},
+		{Id: "01H000000000000000000000B", SchemaVersion: "1.1"},
+	}
+
+	for _, model := range models {
+		if err := ds.WriteAuthorizationModel(ctx, store, model); err != nil {
+			t.Fatalf("WriteAuthorizationModel() returned unexpected error: %v", err)
+		}
+	}
+
+	latest, err := ds.FindLatestAuthorizationModelID(ctx, store)
+	if err != nil {
+		t.Fatalf("FindLatestAuthorizationModelID() returned unexpected error: %v", err)
+	}
+	if latest != models[len(models)-1].GetId() {
+		t.Errorf("expected latest model id %q, got %q", models[len(models)-1].GetId(), latest)
+	}
+
+	got, err := ds.ReadAuthorizationModel(ctx, store, models[0].GetId())
+	if err != nil {
+		t.Fatalf("ReadAuthorizationModel() returned unexpected error: %v", err)
+	}
+	if got.GetId() != models[0].GetId() {
+		t.Errorf("expected model id %q, got %q", models[0].GetId(), got.GetId())
+	}
+}
+
+func testPaginationContinuation(t *testing.T, ds storage.OpenFGADatastore) {
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		mustCreateStore(t, ds, "pagination-store")
+	}
+
+	page1, token, err := ds.ListStores(ctx, storage.PaginationOptions{PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListStores() returned unexpected error: %v", err)
+	}
+	if len(page1) != 2 {
+		t.Fatalf("expected a page of 2 stores, got %d", len(page1))
+	}
+	if len(token) == 0 {
+		t.Fatal("expected a non-empty continuation token when more results remain")
+	}
+
+	page2, _, err := ds.ListStores(ctx, storage.PaginationOptions{PageSize: 2, ContinuationToken: token})
+	if err != nil {
+		t.Fatalf("ListStores() (page 2) returned unexpected error: %v", err)
+	}
+	if len(page2) == 0 {
+		t.Fatal("expected at least one more store on the second page")
+	}
+
+	for _, s := range page1 {
+		for _, s2 := range page2 {
+			if s.GetId() == s2.GetId() {
+				t.Errorf("expected page 1 and page 2 to be disjoint, both contained %q", s.GetId())
+			}
+		}
+	}
+
+	modelStore := mustCreateStore(t, ds, "model-pagination-store")
+	modelIDs := []string{
+		"01H000000000000000000000A",
+		"01H000000000000000000000B",
+		"01H000000000000000000000C",
+	}
+	for _, id := range modelIDs {
+		if err := ds.WriteAuthorizationModel(ctx, modelStore, &openfgav1.AuthorizationModel{Id: id, SchemaVersion: "1.1"}); err != nil {
+			t.Fatalf("WriteAuthorizationModel() returned unexpected error: %v", err)
+		}
+	}
+
+	modelPage1, modelToken, err := ds.ReadAuthorizationModels(ctx, modelStore, storage.PaginationOptions{PageSize: 2})
+	if err != nil {
+		t.Fatalf("ReadAuthorizationModels() returned unexpected error: %v", err)
+	}
+	if len(modelPage1) != 2 {
+		t.Fatalf("expected a page of 2 models, got %d", len(modelPage1))
+	}
+	if len(modelToken) == 0 {
+		t.Fatal("expected a non-empty continuation token when more models remain")
+	}
+
+	modelPage2, _, err := ds.ReadAuthorizationModels(ctx, modelStore, storage.PaginationOptions{PageSize: 2, ContinuationToken: modelToken})
+	if err != nil {
+		t.Fatalf("ReadAuthorizationModels() (page 2) returned unexpected error: %v", err)
+	}
+	if len(modelPage2) != 1 {
+		t.Fatalf("expected the remaining model on the second page, got %d", len(modelPage2))
+	}
+	if modelPage2[0].GetId() != modelIDs[0] {
+		t.Errorf("expected the second page to reach the oldest model %q, got %q", modelIDs[0], modelPage2[0].GetId())
+	}
+
+	for _, m := range modelPage1 {
+		if m.GetId() == modelPage2[0].GetId() {
+			t.Error("expected model pages to be disjoint, but the same model id appeared on both")
+		}
+	}
+
+	tupleStore := mustCreateStore(t, ds, "tuple-pagination-store")
+	tupleKey := &openfgav1.TupleKey{Object: "document:1", Relation: "viewer"}
+	users := []string{"user:anne", "user:bob", "user:carol"}
+	for _, user := range users {
+		key := &openfgav1.TupleKey{Object: tupleKey.GetObject(), Relation: tupleKey.GetRelation(), User: user}
+		if err := ds.Write(ctx, tupleStore, nil, []*openfgav1.TupleKey{key}); err != nil {
+			t.Fatalf("Write() returned unexpected error: %v", err)
+		}
+	}
+
+	tuplePage1, tupleToken, err := ds.ReadPage(ctx, tupleStore, tupleKey, storage.PaginationOptions{PageSize: 2})
+	if err != nil {
+		t.Fatalf("ReadPage() returned unexpected error: %v", err)
+	}
+	if len(tuplePage1) != 2 {
+		t.Fatalf("expected a page of 2 tuples, got %d", len(tuplePage1))
+	}
+	if len(tupleToken) == 0 {
+		t.Fatal("expected a non-empty continuation token when more tuples remain")
+	}
+
+	tuplePage2, _, err := ds.ReadPage(ctx, tupleStore, tupleKey, storage.PaginationOptions{PageSize: 2, ContinuationToken: tupleToken})
+	if err != nil {
+		t.Fatalf("ReadPage() (page 2) returned unexpected error: %v", err)
+	}
+	if len(tuplePage2) != 1 {
+		t.Fatalf("expected the remaining tuple on the second page, got %d", len(tuplePage2))
+	}
+
+	for _, tp := range tuplePage1 {
+		if tp.GetKey().GetUser() == tuplePage2[0].GetKey().GetUser() {
+			t.Error("expected tuple pages to be disjoint, but the same user appeared on both")
+		}
+	}
+
+	changelogStore := mustCreateStore(t, ds, "changelog-pagination-store")
+	changelogObjects := []string{"document:1", "document:2", "document:3"}
+	for _, object := range changelogObjects {
+		key := &openfgav1.TupleKey{Object: object, Relation: "viewer", User: "user:anne"}
+		if err := ds.Write(ctx, changelogStore, nil, []*openfgav1.TupleKey{key}); err != nil {
+			t.Fatalf("Write() returned unexpected error: %v", err)
+		}
+	}
+
+	changePage1, changeToken, err := ds.ReadChanges(ctx, changelogStore, "", storage.PaginationOptions{PageSize: 2}, 0)
+	if err != nil {
+		t.Fatalf("ReadChanges() returned unexpected error: %v", err)
+	}
+	if len(changePage1) != 2 {
+		t.Fatalf("expected a page of 2 changelog entries, got %d", len(changePage1))
+	}
+	if len(changeToken) == 0 {
+		t.Fatal("expected a non-empty continuation token when more changelog entries remain")
+	}
+
+	changePage2, _, err := ds.ReadChanges(ctx, changelogStore, "", storage.PaginationOptions{PageSize: 2, ContinuationToken: changeToken}, 0)
+	if err != nil {
+		t.Fatalf("ReadChanges() (page 2) returned unexpected error: %v", err)
+	}
+	if len(changePage2) != 1 {
+		t.Fatalf("expected the remaining changelog entry on the second page, got %d", len(changePage2))
+	}
+
+	seen := map[string]bool{}
+	for _, c := range append(changePage1, changePage2...) {
+		seen[c.GetTupleKey().GetObject()] = true
+	}
+	for _, object := range changelogObjects {
+		if !seen[object] {
+			t.Errorf("expected changelog entry for %q to be reachable across pages, but it was missing", object)
+		}
+	}
+}
+
+func mustCreateStore(t *testing.T, ds storage.OpenFGADatastore, name string) string {
+	t.Helper()
+
+	store, err := ds.CreateStore(context.Background(), &openfgav1.Store{Name: name})
+	if err != nil {
+		t.Fatalf("CreateStore() returned unexpected error: %v", err)
+	}
+
+	return store.GetId()
+}